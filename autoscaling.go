@@ -0,0 +1,60 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import "context"
+
+// TargetLifecycleState is used to represent the lifecycle state an instance within an
+// Auto Scaling group is transitioning to, as returned by the
+// autoscaling/target-lifecycle-state category
+type TargetLifecycleState string
+
+// Target lifecycle states of an instance within an Auto Scaling group or warm pool
+const (
+	TargetLifecycleStateInService        TargetLifecycleState = "InService"
+	TargetLifecycleStateTerminated       TargetLifecycleState = "Terminated"
+	TargetLifecycleStateWarmedStopped    TargetLifecycleState = "Warmed:Stopped"
+	TargetLifecycleStateWarmedRunning    TargetLifecycleState = "Warmed:Running"
+	TargetLifecycleStateWarmedHibernated TargetLifecycleState = "Warmed:Hibernated"
+	TargetLifecycleStateWarmedTerminated TargetLifecycleState = "Warmed:Terminated"
+)
+
+// SetTargetLifecycleState transitions the target lifecycle state of the instance at
+// runtime, exposed through the autoscaling/target-lifecycle-state category. ErrNotMutable
+// is returned if the container was not started with Mutable
+func (c *client) SetTargetLifecycleState(state TargetLifecycleState) error {
+	if !c.mutable {
+		return ErrNotMutable
+	}
+
+	c.overlay.set(PathAutoscalingTargetLifecycleState, string(state))
+	return nil
+}
+
+// TargetLifecycleState retrieves the target lifecycle state of the instance within an
+// Auto Scaling group. ErrCategoryNotFound is returned if the instance is not part of an
+// Auto Scaling group
+func (c *client) TargetLifecycleState(ctx context.Context) (TargetLifecycleState, error) {
+	state, err := c.value(ctx, PathAutoscalingTargetLifecycleState)
+	return TargetLifecycleState(state), err
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"testing"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartServer_Autoscaling(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Autoscaling: true})
+
+	state, err := server.TargetLifecycleState(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, imds.TargetLifecycleStateInService, state)
+}
+
+func TestStartServer_AutoscalingWarmPool(t *testing.T) {
+	server := startServer(t, imds.Options{
+		RandomPort:           true,
+		Autoscaling:          true,
+		TargetLifecycleState: imds.TargetLifecycleStateWarmedStopped,
+	})
+
+	state, err := server.TargetLifecycleState(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, imds.TargetLifecycleStateWarmedStopped, state)
+}
+
+func TestTargetLifecycleState_NotFound(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	_, err := server.TargetLifecycleState(context.Background())
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+}
+
+func TestSetTargetLifecycleState(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Autoscaling: true, Mutable: true})
+	ctx := context.Background()
+
+	require.NoError(t, server.SetTargetLifecycleState(imds.TargetLifecycleStateTerminated))
+
+	state, err := server.TargetLifecycleState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, imds.TargetLifecycleStateTerminated, state)
+
+	out, _, err := server.Get("autoscaling")
+	require.NoError(t, err)
+	assert.Equal(t, "target-lifecycle-state", out)
+}
+
+func TestSetTargetLifecycleState_NotMutable(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Autoscaling: true})
+
+	err := server.SetTargetLifecycleState(imds.TargetLifecycleStateTerminated)
+	assert.ErrorIs(t, err, imds.ErrNotMutable)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// InProcessEnv is the environment variable that selects an in-process Server in place
+// of a Docker container when starting the imds-mock through New(). It has no effect on
+// Start() or StartWith()
+const InProcessEnv = "IMDS_IN_PROCESS"
+
+// IMDS defines the operations supported by any running instance of the imds-mock,
+// regardless of how it is hosted. Both Container and Server satisfy it
+type IMDS interface {
+	// URL returns the URL for accessing the metadata endpoint
+	URL() string
+
+	// TokenURL returns the URL for accessing the token endpoint
+	TokenURL() string
+
+	// Get will attempt to retrieve an instance category
+	Get(category string) (string, int, error)
+
+	// GetContext behaves in the same way as Get, but the request is bound to the
+	// provided context
+	GetContext(ctx context.Context, category string) (string, int, error)
+
+	// GetV2 will attempt to retrieve an instance category using an authenticated
+	// session token based request
+	GetV2(category, token string) (string, int, error)
+
+	// GetV2Context behaves in the same way as GetV2, but the request is bound to the
+	// provided context
+	GetV2Context(ctx context.Context, category, token string) (string, int, error)
+
+	// TokenWithTTL will attempt to generate a session token with the provided TTL
+	// in seconds
+	TokenWithTTL(ttl int) (string, int, error)
+
+	// TokenWithTTLContext behaves in the same way as TokenWithTTL, but the request is
+	// bound to the provided context
+	TokenWithTTLContext(ctx context.Context, ttl int) (string, int, error)
+
+	// Close will stop the imds-mock, releasing any resources
+	Close() error
+}
+
+var (
+	_ IMDS = (*Container)(nil)
+	_ IMDS = (*Server)(nil)
+)
+
+// New will start an instance of the Instance Metadata Mock (imds-mock), returning it
+// as an IMDS. A Docker container is started by default, unless an in-process Server is
+// selected by either setting the InProcess option or the IMDS_IN_PROCESS environment
+// variable to true. As the caller it is your responsibility to stop the imds-mock by
+// invoking the Close() method
+func New(ctx context.Context, opts Options) (IMDS, error) {
+	// Avoid returning a typed nil, which would not be equal to nil as an IMDS
+	if inProcess(opts) {
+		server, err := StartServer(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return server, nil
+	}
+
+	container, err := StartWith(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return container, nil
+}
+
+// MustNew behaves in the same way as New but panics if the imds-mock cannot be started
+// for any reason. This removes the need to handle any returned errors, simplifying
+// initialisation.
+//
+// As the caller it is your responsibility to stop the imds-mock by invoking the Close()
+// method.
+func MustNew(ctx context.Context, opts Options) IMDS {
+	imds, err := New(ctx, opts)
+	if err != nil {
+		panic(`aemm: MustNew(` + fmt.Sprintf("%#v", opts) + `): ` + err.Error())
+	}
+
+	return imds
+}
+
+func inProcess(opts Options) bool {
+	if opts.InProcess {
+		return true
+	}
+
+	enabled, _ := strconv.ParseBool(os.Getenv(InProcessEnv))
+	return enabled
+}
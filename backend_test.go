@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"testing"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_InProcess(t *testing.T) {
+	mock := newIMDS(t, imds.Options{RandomPort: true, InProcess: true})
+	require.IsType(t, &imds.Server{}, mock)
+
+	assert.Equal(t, imds.ValueInstanceID, instanceID(t, mock))
+}
+
+func TestNew_InProcessEnv(t *testing.T) {
+	t.Setenv(imds.InProcessEnv, "true")
+
+	mock := newIMDS(t, imds.Options{RandomPort: true})
+	require.IsType(t, &imds.Server{}, mock)
+
+	assert.Equal(t, imds.ValueInstanceID, instanceID(t, mock))
+}
+
+func TestNew_InvalidDocument(t *testing.T) {
+	mock, err := imds.New(context.Background(), imds.Options{InProcess: true, DocumentFile: "missing.yaml"})
+	require.Error(t, err)
+	assert.Nil(t, mock)
+}
+
+func TestNew(t *testing.T) {
+	mock := newIMDS(t, imds.Options{RandomPort: true})
+	require.IsType(t, &imds.Container{}, mock)
+
+	assert.Equal(t, imds.ValueInstanceID, instanceID(t, mock))
+}
+
+func TestMustNew_Panics(t *testing.T) {
+	assert.Panics(t, func() {
+		imds.MustNew(context.Background(), imds.Options{InProcess: true, DocumentFile: "missing.yaml"})
+	})
+}
+
+// Utility that consumes any IMDS, regardless of how it is hosted
+func instanceID(t *testing.T, mock imds.IMDS) string {
+	t.Helper()
+
+	out, _, err := mock.Get(imds.PathInstanceID)
+	require.NoError(t, err)
+
+	return out
+}
+
+func newIMDS(t *testing.T, opts imds.Options) imds.IMDS {
+	t.Helper()
+
+	mock, err := imds.New(context.Background(), opts)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		mock.Close()
+	})
+
+	return mock
+}
@@ -27,62 +27,109 @@ package imds
 // view the official AWS documentation at:
 // https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/instancedata-data-categories.html
 const (
-	PathAMIID                                 = "ami-id"
-	PathAMILaunchIndex                        = "ami-launch-index"
-	PathAMIManifestPath                       = "ami-manifest-path"
-	PathBlockDeviceMappingAMI                 = "block-device-mapping/ami"
-	PathBlockDeviceMappingEBS2                = "block-device-mapping/ebs2"
-	PathBlockDeviceMappingRoot                = "block-device-mapping/root"
-	PathEventsMaintenanceHistory              = "events/maintenance/history"
-	PathEventsMaintenanceScheduled            = "events/maintenance/scheduled"
-	PathEventsRecommendationsRebalance        = "events/recommendations/rebalance"
-	PathHostname                              = "hostname"
-	PathIAMInfo                               = "iam/info"
-	PathIAMSecurityCredentials                = "iam/security-credentials/ssm-access"
-	PathInstanceAction                        = "instance-action"
-	PathInstanceID                            = "instance-id"
-	PathInstanceLifecycle                     = "instance-life-cycle"
-	PathInstanceType                          = "instance-type"
-	PathLocalHostname                         = "local-hostname"
-	PathLocalIPv4                             = "local-ipv4"
-	PathMAC                                   = "mac"
-	PathNetworkInterfaces0DeviceNumber        = "network/interfaces/macs/06:e5:43:29:8f:08/device-number"
-	PathNetworkInterfaces0InterfaceID         = "network/interfaces/macs/06:e5:43:29:8f:08/interface-id"
-	PathNetworkInterfaces0LocalHostname       = "network/interfaces/macs/06:e5:43:29:8f:08/local-hostname"
-	PathNetworkInterfaces0LocalIPv4s          = "network/interfaces/macs/06:e5:43:29:8f:08/local-ipv4s"
-	PathNetworkInterfaces0MAC                 = "network/interfaces/macs/06:e5:43:29:8f:08/mac"
-	PathNetworkInterfaces0OwnerID             = "network/interfaces/macs/06:e5:43:29:8f:08/owner-id"
-	PathNetworkInterfaces0SecurityGroups      = "network/interfaces/macs/06:e5:43:29:8f:08/security-groups"
-	PathNetworkInterfaces0SecurityGroupIDs    = "network/interfaces/macs/06:e5:43:29:8f:08/security-group-ids"
-	PathNetworkInterfaces0SubnetID            = "network/interfaces/macs/06:e5:43:29:8f:08/subnet-id"
+	PathAMIID                           = "ami-id"
+	PathAMILaunchIndex                  = "ami-launch-index"
+	PathAMIManifestPath                 = "ami-manifest-path"
+	PathAutoscalingTargetLifecycleState = "autoscaling/target-lifecycle-state"
+	PathBlockDeviceMappingAMI           = "block-device-mapping/ami"
+	PathBlockDeviceMappingEBS2          = "block-device-mapping/ebs2"
+	PathBlockDeviceMappingRoot          = "block-device-mapping/root"
+	PathEventsMaintenanceHistory        = "events/maintenance/history"
+	PathEventsMaintenanceScheduled      = "events/maintenance/scheduled"
+	PathEventsRecommendationsRebalance  = "events/recommendations/rebalance"
+	PathHostname                        = "hostname"
+	PathIAMInfo                         = "iam/info"
+	PathIAMSecurityCredentials          = "iam/security-credentials/ssm-access"
+	PathInstanceAction                  = "instance-action"
+	PathInstanceID                      = "instance-id"
+	PathInstanceLifecycle               = "instance-life-cycle"
+	PathInstanceType                    = "instance-type"
+	PathLocalHostname                   = "local-hostname"
+	PathLocalIPv4                       = "local-ipv4"
+	PathMAC                             = "mac"
+	PathPlacementAvailabilityZone       = "placement/availability-zone"
+	PathPlacementAvailabilityZoneID     = "placement/availability-zone-id"
+	PathPlacementRegion                 = "placement/region"
+	PathProfile                         = "profile"
+	PathPublicKeys0OpenSSHKey           = "public-keys/0/openssh-key"
+	PathReservationID                   = "reservation-id"
+	PathSecurityGroups                  = "security-groups"
+	PathServicesDomain                  = "services/domain"
+	PathServicesPartition               = "services/partition"
+	PathSpotInstanceAction              = "spot/instance-action"
+	PathSpotTerminationTime             = "spot/termination-time"
+	PathTagsInstance                    = "tags/instance"
+)
+
+// Paths to the categories of the single network interface served by the Instance Metadata
+// mock. As multiple network interfaces are supported, use NetworkInterfacePath instead
+const (
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldDeviceNumber) instead
+	PathNetworkInterfaces0DeviceNumber = "network/interfaces/macs/06:e5:43:29:8f:08/device-number"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldInterfaceID) instead
+	PathNetworkInterfaces0InterfaceID = "network/interfaces/macs/06:e5:43:29:8f:08/interface-id"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldLocalHostname) instead
+	PathNetworkInterfaces0LocalHostname = "network/interfaces/macs/06:e5:43:29:8f:08/local-hostname"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldLocalIPv4s) instead
+	PathNetworkInterfaces0LocalIPv4s = "network/interfaces/macs/06:e5:43:29:8f:08/local-ipv4s"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldMAC) instead
+	PathNetworkInterfaces0MAC = "network/interfaces/macs/06:e5:43:29:8f:08/mac"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldOwnerID) instead
+	PathNetworkInterfaces0OwnerID = "network/interfaces/macs/06:e5:43:29:8f:08/owner-id"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldSecurityGroups) instead
+	PathNetworkInterfaces0SecurityGroups = "network/interfaces/macs/06:e5:43:29:8f:08/security-groups"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldSecurityGroupIDs) instead
+	PathNetworkInterfaces0SecurityGroupIDs = "network/interfaces/macs/06:e5:43:29:8f:08/security-group-ids"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldSubnetID) instead
+	PathNetworkInterfaces0SubnetID = "network/interfaces/macs/06:e5:43:29:8f:08/subnet-id"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldSubnetIPv4CIDRBlock) instead
 	PathNetworkInterfaces0SubnetIPv4CIDRBlock = "network/interfaces/macs/06:e5:43:29:8f:08/subnet-ipv4-cidr-block"
-	PathNetworkInterfaces0VPCID               = "network/interfaces/macs/06:e5:43:29:8f:08/vpc-id"
-	PathNetworkInterfaces0VPCIDPv4CIDRBlock   = "network/interfaces/macs/06:e5:43:29:8f:08/vpc-ipv4-cidr-block"
-	PathNetworkInterfaces0VPCIDPv4CIDRBlocks  = "network/interfaces/macs/06:e5:43:29:8f:08/vpc-ipv4-cidr-blocks"
-	PathNetworkInterfaces0VPCIDPv6CIDRBlocks  = "network/interfaces/macs/06:e5:43:29:8f:08/vpc-ipv6-cidr-blocks"
-	PathPlacementAvailabilityZone             = "placement/availability-zone"
-	PathPlacementAvailabilityZoneID           = "placement/availability-zone-id"
-	PathPlacementRegion                       = "placement/region"
-	PathProfile                               = "profile"
-	PathPublicKeys0OpenSSHKey                 = "public-keys/0/openssh-key"
-	PathReservationID                         = "reservation-id"
-	PathSecurityGroups                        = "security-groups"
-	PathServicesDomain                        = "services/domain"
-	PathServicesPartition                     = "services/partition"
-	PathSpotInstanceAction                    = "spot/instance-action"
-	PathSpotTerminationTime                   = "spot/termination-time"
-	PathTagsInstance                          = "tags/instance"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldVPCID) instead
+	PathNetworkInterfaces0VPCID = "network/interfaces/macs/06:e5:43:29:8f:08/vpc-id"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldVPCIPv4CIDRBlock) instead
+	PathNetworkInterfaces0VPCIDPv4CIDRBlock = "network/interfaces/macs/06:e5:43:29:8f:08/vpc-ipv4-cidr-block"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldVPCIPv4CIDRBlocks) instead
+	PathNetworkInterfaces0VPCIDPv4CIDRBlocks = "network/interfaces/macs/06:e5:43:29:8f:08/vpc-ipv4-cidr-blocks"
+
+	// Deprecated: use NetworkInterfacePath(ValueMAC, InterfaceFieldVPCIPv6CIDRBlocks) instead
+	PathNetworkInterfaces0VPCIDPv6CIDRBlocks = "network/interfaces/macs/06:e5:43:29:8f:08/vpc-ipv6-cidr-blocks"
+)
+
+// Dynamic data categories are served from a separate endpoint to instance metadata. Each
+// category is only available if the container was started with InstanceIdentity. To find a
+// comprehensive description of each category, view the official AWS documentation at:
+// https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/instancedata-dynamic-data-retrieval.html
+const (
+	DynamicPathInstanceIdentityDocument  = "instance-identity/document"
+	DynamicPathInstanceIdentityPKCS7     = "instance-identity/pkcs7"
+	DynamicPathInstanceIdentityRSA2048   = "instance-identity/rsa2048"
+	DynamicPathInstanceIdentitySignature = "instance-identity/signature"
 )
 
 // Instance Metadata values as returned by the Instance Metadata mock for each supported category.
 // Values are not provided for the following categories, as the Instance Metadata mock returns
-// dated values:
+// dated values. Maintenance events can be injected at runtime through ScheduleMaintenanceEvent:
 //
 //   - events/maintenance/history
 //   - events/maintenance/scheduled
 //   - events/recommendations/rebalance
 //   - spot/instance-action
 //   - spot/termination-time
+//
+// ValueIAMSecurityCredentials holds the static credentials of the mock, which have already
+// expired. They are replaced by generated credentials, unless served from a Document
 const (
 	ValueAMIID                                 = "ami-0e34bbddc66def5ac"
 	ValueAMILaunchIndex                        = "0"
@@ -137,3 +184,9 @@ const (
 func InstanceTagPath(tag string) string {
 	return PathTagsInstance + "/" + tag
 }
+
+// IAMSecurityCredentialsPath generates a security credentials category path based on
+// the provided IAM role
+func IAMSecurityCredentialsPath(role string) string {
+	return "iam/security-credentials/" + role
+}
@@ -42,7 +42,6 @@ func TestCategory(t *testing.T) {
 	t.Run("BlockDeviceMappingRoot", checkIMDSCategory(container, imds.PathBlockDeviceMappingRoot, imds.ValueBlockDeviceMappingRoot))
 	t.Run("Hostname", checkIMDSCategory(container, imds.PathHostname, imds.ValueHostname))
 	t.Run("IAMInfo", checkIMDSCategory(container, imds.PathIAMInfo, imds.ValueIAMInfo))
-	t.Run("IAMSecurityCredentials", checkIMDSCategory(container, imds.PathIAMSecurityCredentials, imds.ValueIAMSecurityCredentials))
 	t.Run("InstanceAction", checkIMDSCategory(container, imds.PathInstanceAction, imds.ValueInstanceAction))
 	t.Run("InstanceID", checkIMDSCategory(container, imds.PathInstanceID, imds.ValueInstanceID))
 	t.Run("InstanceLifecycle", checkIMDSCategory(container, imds.PathInstanceLifecycle, imds.ValueInstanceLifecycle))
@@ -0,0 +1,295 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// client provides access to the instance metadata served by the imds-mock, independent
+// of how it is hosted
+type client struct {
+	endpoint    string
+	metadataURL string
+	tokenURL    string
+	httpClient  *http.Client
+	token       *sessionToken
+	overlay     *overlay
+	identity    *identity
+	mutable     bool
+	rebalance   *time.Timer
+	rebalanceMu sync.Mutex
+	maintenance *maintenanceEvents
+	scenario    *ScenarioClock
+}
+
+// newClient creates a client for the imds-mock served at the given endpoint
+func newClient(endpoint string) *client {
+	c := &client{httpClient: &http.Client{}, maintenance: &maintenanceEvents{}}
+	c.setEndpoint(endpoint)
+	return c
+}
+
+// setEndpoint updates the client to target the imds-mock served at the given endpoint
+func (c *client) setEndpoint(endpoint string) {
+	c.endpoint = endpoint
+	c.metadataURL = endpoint + "/latest/meta-data/"
+	c.tokenURL = endpoint + "/latest/api/token"
+}
+
+// startOverlay serves an overlay on top of the upstream imds-mock from the host, populated
+// from the instance metadata document and options. The client is updated to target
+// the overlay upon success. Any scheduled rebalance recommendation is canceled upon failure
+func (c *client) startOverlay(ctx context.Context, upstream http.Handler, document map[string]string, opts Options) (_ *http.Server, err error) {
+	defer func() {
+		if err != nil {
+			c.cancelRebalanceRecommendation()
+		}
+	}()
+
+	c.overlay = newOverlay(upstream, opts.Pretty)
+	c.overlay.replace = document != nil
+	c.mutable = opts.Mutable || opts.Scenario != nil
+	for category, value := range document {
+		c.overlay.set(category, value)
+	}
+
+	// Replace the expired credentials of the mock, unless a document is provided
+	if document == nil || opts.IAMRole != DefaultIAMRole || opts.CredentialsLifetime > 0 {
+		if err := serveCredentials(c.overlay, opts.IAMRole, opts.CredentialsLifetime); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(opts.NetworkInterfaces) > 0 {
+		if err := serveNetworkInterfaces(c.overlay, opts.NetworkInterfaces); err != nil {
+			return nil, err
+		}
+	}
+
+	for category, value := range opts.Overrides {
+		c.overlay.set(category, value)
+	}
+
+	if opts.Rebalance {
+		if err := c.scheduleRebalanceRecommendation(opts.RebalanceDelay); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Autoscaling {
+		c.overlay.set(PathAutoscalingTargetLifecycleState, string(opts.TargetLifecycleState))
+	}
+
+	if opts.UserData != nil {
+		c.overlay.setRaw(userDataPath, opts.UserData)
+	}
+
+	port := opts.ExposedPort
+	if opts.RandomPort {
+		port = "0"
+	}
+
+	srv, endpoint, err := serveOverlay(c.overlay, port)
+	if err != nil {
+		return nil, err
+	}
+	c.setEndpoint(endpoint)
+
+	if opts.Scenario != nil {
+		if c.scenario, err = c.PlayScenario(ctx, *opts.Scenario); err != nil {
+			srv.Close()
+			return nil, err
+		}
+	}
+
+	if opts.InstanceIdentity {
+		if err := c.serveInstanceIdentity(ctx); err != nil {
+			srv.Close()
+			return nil, err
+		}
+	}
+
+	return srv, nil
+}
+
+// URL returns the URL for accessing the metadata endpoint of the container. The host
+// and port are resolved from the proxy on the host, which sits in front of the container
+//
+//	http://<HOST>:<MAPPED_PORT>/latest/meta-data/
+func (c *client) URL() string {
+	return c.metadataURL
+}
+
+// TokenURL returns the URL for accessing the token endpoint of the container. The host
+// and port are resolved from the proxy on the host, which sits in front of the container
+//
+//	http://<HOST>:<MAPPED_PORT>/latest/api/token
+func (c *client) TokenURL() string {
+	return c.tokenURL
+}
+
+// Get will attempt to retrieve an instance category from the running container. The raw
+// value of the category will be returned from the container upon success. If the
+// container was started with ManageToken, an IMDSv2 session token will be transparently
+// attached to the request
+//
+// Any unexpected status code is returned as a StatusError, which can be matched using
+// errors.Is. A failure to connect to the container is returned as a ConnectionError
+//
+// Status Codes:
+//
+//	200: category was retrieved
+//	404: category does not exist (ErrCategoryNotFound)
+func (c *client) Get(category string) (string, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	return c.GetContext(ctx, category)
+}
+
+// GetContext behaves in the same way as Get, but the request is bound to the provided
+// context. Cancelling the context, or exceeding its deadline, will abort the request
+// and return the context error
+func (c *client) GetContext(ctx context.Context, category string) (string, int, error) {
+	return c.getManaged(ctx, c.metadataURL, category)
+}
+
+// GetDynamic will attempt to retrieve a dynamic data category from the running container,
+// such as the instance identity document. It behaves in the same way as Get, including
+// the transparent management of IMDSv2 session tokens. Dynamic data categories are only
+// available if the container was started with InstanceIdentity
+func (c *client) GetDynamic(category string) (string, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	return c.GetDynamicContext(ctx, category)
+}
+
+// GetDynamicContext behaves in the same way as GetDynamic, but the request is bound to
+// the provided context. Cancelling the context, or exceeding its deadline, will abort
+// the request and return the context error
+func (c *client) GetDynamicContext(ctx context.Context, category string) (string, int, error) {
+	return c.getManaged(ctx, c.endpoint+dynamicPrefix, category)
+}
+
+// GetV2 will attempt to retrieve an instance category from the running container
+// using an authenticated session token based request. If the container was not started
+// in IMDSv2 mode, the token will have no effect. The raw value of the category will
+// be returned from the container upon success
+//
+// Any unexpected status code is returned as a StatusError, which can be matched using
+// errors.Is. A failure to connect to the container is returned as a ConnectionError
+//
+// Status Codes:
+//   - 200: category was retrieved
+//   - 404: category does not exist (ErrCategoryNotFound)
+//   - 401: session token is either invalid or expired (ErrUnauthorized)
+func (c *client) GetV2(category, token string) (string, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	return c.GetV2Context(ctx, category, token)
+}
+
+// GetV2Context behaves in the same way as GetV2, but the request is bound to the provided
+// context. Cancelling the context, or exceeding its deadline, will abort the request
+// and return the context error
+func (c *client) GetV2Context(ctx context.Context, category, token string) (string, int, error) {
+	return c.get(ctx, c.metadataURL, category, token)
+}
+
+// get retrieves a path relative to the base URL, attaching the session token if provided
+func (c *client) get(ctx context.Context, base, path, token string) (string, int, error) {
+	pathURL, _ := url.JoinPath(base, path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pathURL, http.NoBody)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if token != "" {
+		req.Header.Add("X-aws-ec2-metadata-token", token)
+	}
+
+	return c.do(req, path)
+}
+
+// TokenWithTTL will attempt to generate a session token with the provided TTL in seconds.
+//
+// Any unexpected status code is returned as a StatusError, which can be matched using
+// errors.Is. A failure to connect to the container is returned as a ConnectionError
+//
+// Status Codes:
+//
+//	200: token was created
+//	400: TTL was outside the expected bounds (min: 1, max: 21600) (ErrInvalidTTL)
+func (c *client) TokenWithTTL(ttl int) (string, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	return c.TokenWithTTLContext(ctx, ttl)
+}
+
+// TokenWithTTLContext behaves in the same way as TokenWithTTL, but the request is bound
+// to the provided context. Cancelling the context, or exceeding its deadline, will abort
+// the request and return the context error
+func (c *client) TokenWithTTLContext(ctx context.Context, ttl int) (string, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.tokenURL, http.NoBody)
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Add("X-aws-ec2-metadata-token-ttl-seconds", strconv.Itoa(ttl))
+
+	return c.do(req, "")
+}
+
+func (c *client) do(req *http.Request, category string) (string, int, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", 0, &ConnectionError{Err: err}
+	}
+
+	data, _ := io.ReadAll(resp.Body)
+	if err := resp.Body.Close(); err != nil {
+		return "", 0, &ConnectionError{Err: err}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return string(data), resp.StatusCode, &StatusError{
+			Method:     req.Method,
+			URL:        req.URL.String(),
+			Category:   category,
+			StatusCode: resp.StatusCode,
+			Body:       string(data),
+		}
+	}
+
+	return string(data), resp.StatusCode, nil
+}
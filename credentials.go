@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+)
+
+// DefaultIAMRole is the name of the IAM role associated with the instance by the
+// Instance Metadata mock
+const DefaultIAMRole = "ssm-access"
+
+// defaultCredentialsLifetime matches the typical lifetime of credentials issued by IMDS
+const defaultCredentialsLifetime = 6 * time.Hour
+
+// credentialSource generates temporary security credentials for an IAM role, rotating
+// them at the end of each lifetime. Credentials remain stable within a lifetime
+type credentialSource struct {
+	seed     []byte
+	start    time.Time
+	lifetime time.Duration
+}
+
+func newCredentialSource(lifetime time.Duration) (*credentialSource, error) {
+	seed := make([]byte, 16)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+
+	return &credentialSource{
+		seed:     seed,
+		start:    time.Now().UTC().Truncate(time.Second),
+		lifetime: lifetime,
+	}, nil
+}
+
+// at returns the credentials that are valid at the given time
+func (s *credentialSource) at(now time.Time) SecurityCredentials {
+	window := int64(0)
+	if elapsed := now.Sub(s.start); elapsed > 0 {
+		window = int64(elapsed / s.lifetime)
+	}
+	issued := s.start.Add(time.Duration(window) * s.lifetime)
+
+	return SecurityCredentials{
+		Code:            "Success",
+		LastUpdated:     issued,
+		Type:            "AWS-HMAC",
+		AccessKeyID:     "ASIA" + base32.StdEncoding.EncodeToString(s.derive(window, "access-key-id", 10)),
+		SecretAccessKey: base64.StdEncoding.EncodeToString(s.derive(window, "secret-access-key", 30)),
+		Token:           base64.StdEncoding.EncodeToString(s.derive(window, "token", 96)),
+		Expiration:      issued.Add(s.lifetime),
+	}
+}
+
+// derive a deterministic value of n bytes for the window and purpose
+func (s *credentialSource) derive(window int64, purpose string, n int) []byte {
+	var out []byte
+	for block := uint32(0); len(out) < n; block++ {
+		h := sha256.New()
+		h.Write(s.seed)
+		binary.Write(h, binary.BigEndian, window)
+		binary.Write(h, binary.BigEndian, block)
+		h.Write([]byte(purpose))
+		out = h.Sum(out)
+	}
+
+	return out[:n]
+}
+
+// serve the current credentials as JSON
+func (s *credentialSource) serve() string {
+	out, _ := json.Marshal(s.at(time.Now()))
+	return string(out)
+}
+
+// serveCredentials serves security credentials for the IAM role through the overlay,
+// replacing the expired static credentials of the mock. If no lifetime is provided,
+// credentials are generated using the default lifetime
+func serveCredentials(o *overlay, role string, lifetime time.Duration) error {
+	if role != DefaultIAMRole {
+		o.hide(IAMSecurityCredentialsPath(DefaultIAMRole))
+	}
+
+	if lifetime <= 0 {
+		lifetime = defaultCredentialsLifetime
+	}
+
+	source, err := newCredentialSource(lifetime)
+	if err != nil {
+		return err
+	}
+
+	o.generate(IAMSecurityCredentialsPath(role), source.serve)
+	return nil
+}
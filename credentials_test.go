@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartServer_IAMRole(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, IAMRole: "web-app"})
+
+	roles, _, err := server.Get("iam/security-credentials/")
+	require.NoError(t, err)
+	assert.Equal(t, "web-app", roles)
+
+	creds, err := server.SecurityCredentials(context.Background())
+	require.NoError(t, err)
+	assert.True(t, creds.Expiration.After(time.Now()))
+
+	_, _, err = server.Get(imds.PathIAMSecurityCredentials)
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+}
+
+func TestStartServer_CredentialsLifetime(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, CredentialsLifetime: time.Second})
+
+	first, err := server.SecurityCredentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Success", first.Code)
+	assert.Equal(t, time.Second, first.Expiration.Sub(first.LastUpdated))
+	assert.Regexp(t, "^ASIA[A-Z2-7]{16}$", first.AccessKeyID)
+
+	time.Sleep(time.Until(first.Expiration))
+
+	rotated, err := server.SecurityCredentials(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, first.AccessKeyID, rotated.AccessKeyID)
+	assert.NotEqual(t, first.Token, rotated.Token)
+	assert.True(t, rotated.Expiration.After(first.Expiration))
+}
+
+func TestStartServer_CredentialsStable(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, CredentialsLifetime: time.Hour})
+
+	first, err := server.SecurityCredentials(context.Background())
+	require.NoError(t, err)
+
+	same, err := server.SecurityCredentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, first, same)
+}
+
+func TestStartServer_DefaultCredentials(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	creds, err := server.SecurityCredentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 6*time.Hour, creds.Expiration.Sub(creds.LastUpdated))
+	assert.True(t, creds.Expiration.After(time.Now()))
+}
@@ -0,0 +1,239 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/purpleclay/imds-mock/pkg/imds/patch"
+)
+
+// MaintenanceEventTimeFormat defines the layout of any timestamp associated with a
+// scheduled maintenance event
+//
+//	21 Jan 2019 09:00:43 GMT
+const MaintenanceEventTimeFormat = "2 Jan 2006 15:04:05 GMT"
+
+// IAMInfo contains details about the IAM instance profile associated with the instance,
+// as returned by the iam/info category
+type IAMInfo struct {
+	Code               string    `json:"Code"`
+	LastUpdated        time.Time `json:"LastUpdated"`
+	InstanceProfileARN string    `json:"InstanceProfileArn"`
+	InstanceProfileID  string    `json:"InstanceProfileId"`
+}
+
+// SecurityCredentials contains the temporary security credentials associated with
+// an IAM role, as returned by the iam/security-credentials/<ROLE> category
+type SecurityCredentials struct {
+	Code            string    `json:"Code"`
+	LastUpdated     time.Time `json:"LastUpdated"`
+	Type            string    `json:"Type"`
+	AccessKeyID     string    `json:"AccessKeyId"`
+	SecretAccessKey string    `json:"SecretAccessKey"`
+	Token           string    `json:"Token"`
+	Expiration      time.Time `json:"Expiration"`
+}
+
+// SpotInstanceAction contains details of a spot interruption notice, as returned by
+// the spot/instance-action category
+type SpotInstanceAction struct {
+	Action patch.SpotInstanceAction `json:"action"`
+	Time   time.Time                `json:"time"`
+}
+
+// RebalanceRecommendation contains details of a rebalance recommendation signal, as
+// returned by the events/recommendations/rebalance category
+type RebalanceRecommendation struct {
+	NoticeTime time.Time `json:"noticeTime"`
+}
+
+// MaintenanceEvent contains details of a scheduled maintenance event, as returned by
+// either the events/maintenance/scheduled or events/maintenance/history categories
+type MaintenanceEvent struct {
+	Code              string
+	Description       string
+	EventID           string
+	NotBefore         time.Time
+	NotAfter          time.Time
+	NotBeforeDeadline time.Time
+	State             string
+}
+
+// maintenanceEventJSON mirrors the JSON representation of a maintenance event, where
+// all timestamps are formatted using MaintenanceEventTimeFormat
+type maintenanceEventJSON struct {
+	Code              string `json:"Code"`
+	Description       string `json:"Description"`
+	EventID           string `json:"EventId"`
+	NotBefore         string `json:"NotBefore"`
+	NotAfter          string `json:"NotAfter"`
+	NotBeforeDeadline string `json:"NotBeforeDeadline,omitempty"`
+	State             string `json:"State"`
+}
+
+// MarshalJSON encodes a maintenance event into the same JSON format as IMDS
+func (e MaintenanceEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(maintenanceEventJSON{
+		Code:              e.Code,
+		Description:       e.Description,
+		EventID:           e.EventID,
+		NotBefore:         formatMaintenanceTime(e.NotBefore),
+		NotAfter:          formatMaintenanceTime(e.NotAfter),
+		NotBeforeDeadline: formatMaintenanceTime(e.NotBeforeDeadline),
+		State:             e.State,
+	})
+}
+
+// UnmarshalJSON decodes a maintenance event from the JSON format used by IMDS
+func (e *MaintenanceEvent) UnmarshalJSON(data []byte) error {
+	var raw maintenanceEventJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if e.NotBefore, err = parseMaintenanceTime(raw.NotBefore); err != nil {
+		return err
+	}
+
+	if e.NotAfter, err = parseMaintenanceTime(raw.NotAfter); err != nil {
+		return err
+	}
+
+	if e.NotBeforeDeadline, err = parseMaintenanceTime(raw.NotBeforeDeadline); err != nil {
+		return err
+	}
+
+	e.Code = raw.Code
+	e.Description = raw.Description
+	e.EventID = raw.EventID
+	e.State = raw.State
+	return nil
+}
+
+func formatMaintenanceTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(MaintenanceEventTimeFormat)
+}
+
+func parseMaintenanceTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(MaintenanceEventTimeFormat, value)
+}
+
+// IAMInfo retrieves and decodes details about the IAM instance profile associated
+// with the instance
+func (c *client) IAMInfo(ctx context.Context) (IAMInfo, error) {
+	var info IAMInfo
+	err := c.decode(ctx, PathIAMInfo, &info)
+	return info, err
+}
+
+// SecurityCredentials retrieves and decodes the temporary security credentials of
+// the IAM role associated with the instance. The name of the role is discovered from
+// the iam/security-credentials category
+func (c *client) SecurityCredentials(ctx context.Context) (SecurityCredentials, error) {
+	roles, err := c.value(ctx, IAMSecurityCredentialsPath(""))
+	if err != nil {
+		return SecurityCredentials{}, err
+	}
+
+	role, _, _ := strings.Cut(roles, "\n")
+
+	var creds SecurityCredentials
+	err = c.decode(ctx, IAMSecurityCredentialsPath(role), &creds)
+	return creds, err
+}
+
+// SpotInstanceAction retrieves and decodes the spot interruption notice of the instance.
+// ErrCategoryNotFound is returned if no spot interruption notice has been raised
+func (c *client) SpotInstanceAction(ctx context.Context) (SpotInstanceAction, error) {
+	var action SpotInstanceAction
+	err := c.decode(ctx, PathSpotInstanceAction, &action)
+	return action, err
+}
+
+// SpotTerminationTime retrieves and parses the time at which a spot instance will
+// be terminated. ErrCategoryNotFound is returned if no termination has been scheduled
+func (c *client) SpotTerminationTime(ctx context.Context) (time.Time, error) {
+	value, err := c.value(ctx, PathSpotTerminationTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	terminationTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("instance category %q is not a valid RFC3339 timestamp: %w", PathSpotTerminationTime, err)
+	}
+
+	return terminationTime, nil
+}
+
+// RebalanceRecommendation retrieves and decodes the rebalance recommendation signal of
+// the instance. ErrCategoryNotFound is returned if no recommendation has been raised
+func (c *client) RebalanceRecommendation(ctx context.Context) (RebalanceRecommendation, error) {
+	var recommendation RebalanceRecommendation
+	err := c.decode(ctx, PathEventsRecommendationsRebalance, &recommendation)
+	return recommendation, err
+}
+
+// ScheduledMaintenanceEvents retrieves and decodes any active maintenance events
+// scheduled against the instance
+func (c *client) ScheduledMaintenanceEvents(ctx context.Context) ([]MaintenanceEvent, error) {
+	var events []MaintenanceEvent
+	err := c.decode(ctx, PathEventsMaintenanceScheduled, &events)
+	return events, err
+}
+
+// MaintenanceEventHistory retrieves and decodes any maintenance events that have
+// either been completed or canceled
+func (c *client) MaintenanceEventHistory(ctx context.Context) ([]MaintenanceEvent, error) {
+	var events []MaintenanceEvent
+	err := c.decode(ctx, PathEventsMaintenanceHistory, &events)
+	return events, err
+}
+
+// decode retrieves a JSON instance category and unmarshals it into the provided value
+func (c *client) decode(ctx context.Context, category string, v any) error {
+	value, err := c.value(ctx, category)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(value), v); err != nil {
+		return fmt.Errorf("instance category %q could not be decoded: %w", category, err)
+	}
+
+	return nil
+}
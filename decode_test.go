@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/purpleclay/imds-mock/pkg/imds/patch"
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIAMInfo(t *testing.T) {
+	container := startWithDefaults(t)
+
+	info, err := container.IAMInfo(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, imds.IAMInfo{
+		Code:               "Success",
+		LastUpdated:        time.Date(2022, time.August, 8, 4, 25, 36, 0, time.UTC),
+		InstanceProfileARN: "arn:aws:iam::112233445566:instance-profile/ssm-access",
+		InstanceProfileID:  "AIPAYUKXDENX4ZNCZWHF6",
+	}, info)
+}
+
+func TestSecurityCredentials(t *testing.T) {
+	container := startWithDefaults(t)
+
+	creds, err := container.SecurityCredentials(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "Success", creds.Code)
+	assert.Equal(t, "AWS-HMAC", creds.Type)
+	assert.True(t, strings.HasPrefix(creds.AccessKeyID, "ASIA"))
+	assert.NotEmpty(t, creds.SecretAccessKey)
+	assert.NotEmpty(t, creds.Token)
+	assert.Equal(t, 6*time.Hour, creds.Expiration.Sub(creds.LastUpdated))
+	assert.True(t, creds.Expiration.After(time.Now()))
+}
+
+func TestSpotInstanceAction(t *testing.T) {
+	container := startWithOptions(t, imds.Options{Spot: true})
+	ctx := context.Background()
+
+	action, err := container.SpotInstanceAction(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, patch.TerminateSpotInstanceAction, action.Action)
+	assert.False(t, action.Time.IsZero())
+
+	terminationTime, err := container.SpotTerminationTime(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, action.Time, terminationTime)
+}
+
+func TestSpotInstanceAction_NotFound(t *testing.T) {
+	container := startWithDefaults(t)
+
+	_, err := container.SpotInstanceAction(context.Background())
+
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+}
+
+func TestRebalanceRecommendation(t *testing.T) {
+	container := startWithOptions(t, imds.Options{Spot: true})
+
+	recommendation, err := container.RebalanceRecommendation(context.Background())
+
+	require.NoError(t, err)
+	assert.False(t, recommendation.NoticeTime.IsZero())
+}
+
+func TestScheduledMaintenanceEvents(t *testing.T) {
+	container := startWithDefaults(t)
+
+	events, err := container.ScheduledMaintenanceEvents(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestMaintenanceEventJSON(t *testing.T) {
+	data := `{"NotBefore":"21 Jan 2019 09:00:43 GMT","Code":"system-reboot","Description":"scheduled reboot",` +
+		`"EventId":"instance-event-0d59937288b749b32","NotAfter":"21 Jan 2019 09:17:23 GMT","State":"active"}`
+
+	var event imds.MaintenanceEvent
+	require.NoError(t, json.Unmarshal([]byte(data), &event))
+
+	assert.Equal(t, imds.MaintenanceEvent{
+		Code:        "system-reboot",
+		Description: "scheduled reboot",
+		EventID:     "instance-event-0d59937288b749b32",
+		NotBefore:   time.Date(2019, time.January, 21, 9, 0, 43, 0, time.UTC),
+		NotAfter:    time.Date(2019, time.January, 21, 9, 17, 23, 0, time.UTC),
+		State:       "active",
+	}, event)
+
+	out, err := json.Marshal(event)
+	require.NoError(t, err)
+	assert.JSONEq(t, data, string(out))
+}
@@ -0,0 +1,192 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Instance categories that contain a JSON document, rather than a set of child
+// categories. The security credentials of every IAM role are also served as JSON
+var jsonCategories = map[string]struct{}{
+	PathIAMInfo:                        {},
+	PathSpotInstanceAction:             {},
+	PathEventsMaintenanceHistory:       {},
+	PathEventsMaintenanceScheduled:     {},
+	PathEventsRecommendationsRebalance: {},
+}
+
+func isJSONCategory(category string) bool {
+	if _, ok := jsonCategories[category]; ok {
+		return true
+	}
+
+	role := strings.TrimPrefix(category, "iam/security-credentials/")
+	return role != category && role != "" && !strings.Contains(role, "/")
+}
+
+// readDocumentOptions reads the instance metadata document defined within the options.
+// Nil is returned if no document was provided
+func readDocumentOptions(opts Options) (map[string]string, error) {
+	if opts.Document == nil && opts.DocumentFile == "" {
+		return nil, nil
+	}
+
+	return readDocument(opts.Document, opts.DocumentFile)
+}
+
+// readDocument reads an instance metadata document from either the reader or the file
+// path, flattening it into a set of instance categories keyed by their path. The
+// document can be written in either JSON or YAML
+func readDocument(r io.Reader, path string) (map[string]string, error) {
+	if r == nil {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		r = file
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// As YAML is a superset of JSON, a single decoder supports both formats
+	var document map[string]any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("instance metadata document is neither valid JSON nor YAML: %w", err)
+	}
+
+	categories := map[string]string{}
+	if document == nil {
+		return categories, nil
+	}
+
+	if err := flatten("", normalise(document), categories); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// flatten recursively walks a decoded document, storing the value of every instance
+// category against its path. Any JSON category is stored as encoded JSON, while a list
+// of values is stored one per line, in the same way as IMDS
+func flatten(category string, value any, categories map[string]string) error {
+	if isJSONCategory(category) {
+		if _, ok := value.(string); !ok {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("instance category %q could not be encoded as JSON: %w", category, err)
+			}
+			categories[category] = string(data)
+			return nil
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			path := key
+			if category != "" {
+				path = category + "/" + key
+			}
+
+			if err := flatten(path, child, categories); err != nil {
+				return err
+			}
+		}
+	case []any:
+		lines := make([]string, 0, len(v))
+		for _, item := range v {
+			line, err := scalar(category, item)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, line)
+		}
+		categories[category] = strings.Join(lines, "\n")
+	default:
+		line, err := scalar(category, v)
+		if err != nil {
+			return err
+		}
+		categories[category] = line
+	}
+
+	return nil
+}
+
+// scalar formats a single value of an instance category
+func scalar(category string, value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	}
+
+	return "", fmt.Errorf("instance category %q has an unsupported value of type %T", category, value)
+}
+
+// normalise converts a decoded YAML value into its JSON equivalent. Maps with non-string
+// keys, such as public-keys/0, have their keys converted into strings, and timestamps
+// are formatted as RFC3339
+func normalise(value any) any {
+	switch v := value.(type) {
+	case map[any]any:
+		obj := make(map[string]any, len(v))
+		for key, child := range v {
+			obj[fmt.Sprint(key)] = normalise(child)
+		}
+		return obj
+	case map[string]any:
+		obj := make(map[string]any, len(v))
+		for key, child := range v {
+			obj[key] = normalise(child)
+		}
+		return obj
+	case []any:
+		list := make([]any, len(v))
+		for i, child := range v {
+			list[i] = normalise(child)
+		}
+		return list
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	}
+
+	return value
+}
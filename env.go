@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Environment variables used by the AWS SDKs and CLI to configure access to IMDS
+const (
+	EnvEndpoint     = "AWS_EC2_METADATA_SERVICE_ENDPOINT"
+	EnvEndpointMode = "AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE"
+	EnvDisabled     = "AWS_EC2_METADATA_DISABLED"
+)
+
+// Env returns the environment variables needed by any AWS SDK or CLI to retrieve instance
+// metadata from the mock. The endpoint is derived from its URL, with the endpoint mode
+// set to IPv6 if the URL contains an IPv6 address. Each variable is in the form key=value,
+// supporting direct use when executing a child process:
+//
+//	cmd := exec.Command("./agent")
+//	cmd.Env = append(os.Environ(), imds.Env(container)...)
+func Env(mock IMDS) []string {
+	endpoint := strings.TrimSuffix(mock.URL(), "/latest/meta-data/")
+
+	mode := "IPv4"
+	if u, err := url.Parse(endpoint); err == nil && strings.Contains(u.Hostname(), ":") {
+		mode = "IPv6"
+	}
+
+	return []string{
+		EnvEndpoint + "=" + endpoint,
+		EnvEndpointMode + "=" + mode,
+		EnvDisabled + "=false",
+	}
+}
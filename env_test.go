@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"testing"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnv(t *testing.T) {
+	server := startServer(t, imds.Options{ExposedPort: "1342"})
+
+	assert.ElementsMatch(t, []string{
+		"AWS_EC2_METADATA_SERVICE_ENDPOINT=http://localhost:1342",
+		"AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE=IPv4",
+		"AWS_EC2_METADATA_DISABLED=false",
+	}, imds.Env(server))
+}
+
+type fakeIMDS struct {
+	imds.IMDS
+	url string
+}
+
+func (f fakeIMDS) URL() string {
+	return f.url
+}
+
+func TestEnv_IPv6(t *testing.T) {
+	mock := fakeIMDS{url: "http://[fd00:ec2::254]/latest/meta-data/"}
+
+	assert.ElementsMatch(t, []string{
+		"AWS_EC2_METADATA_SERVICE_ENDPOINT=http://[fd00:ec2::254]",
+		"AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE=IPv6",
+		"AWS_EC2_METADATA_DISABLED=false",
+	}, imds.Env(mock))
+}
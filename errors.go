@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrCategoryNotFound is returned when a requested instance category does not exist
+	ErrCategoryNotFound = errors.New("instance category not found")
+
+	// ErrUnauthorized is returned when a request is rejected due to a missing, invalid
+	// or expired session token. Only applicable when IMDSv2 has been enforced
+	ErrUnauthorized = errors.New("session token missing, invalid or expired")
+
+	// ErrInvalidTTL is returned when a session token is requested with a TTL outside
+	// of the supported bounds (min: 1, max: 21600)
+	ErrInvalidTTL = errors.New("session token TTL outside of supported bounds")
+
+	// ErrConnection is returned when a request could not be sent to the container,
+	// or a response could not be read
+	ErrConnection = errors.New("failed to communicate with container")
+
+	// ErrNotMutable is returned when attempting to change instance metadata at runtime,
+	// without starting the container with the Mutable option
+	ErrNotMutable = errors.New("instance metadata cannot be changed at runtime")
+
+	// ErrMaintenanceEventNotFound is returned when attempting to complete or cancel a
+	// maintenance event that has not been scheduled
+	ErrMaintenanceEventNotFound = errors.New("maintenance event not found")
+
+	// ErrClockBackwards is returned when attempting to advance the virtual clock of a
+	// scenario by a negative duration
+	ErrClockBackwards = errors.New("scenario clock cannot move backwards")
+)
+
+// StatusError is returned when the container responds with an unexpected status
+// code. It retains both the raw status code and body of the response for inspection.
+// Use errors.Is to check for a known failure, such as ErrCategoryNotFound
+type StatusError struct {
+	// Method of the request
+	Method string
+
+	// URL of the request
+	URL string
+
+	// Category that was requested. This will be empty if the error was raised
+	// when requesting a session token
+	Category string
+
+	// StatusCode returned by the container
+	StatusCode int
+
+	// Body contains the raw response returned by the container
+	Body string
+}
+
+// Error returns a formatted description of the error
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request to %s returned status %d", e.URL, e.StatusCode)
+}
+
+// Unwrap returns the known failure that matches the status code of the error
+func (e *StatusError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrCategoryNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusBadRequest:
+		// Only a session token request can be rejected due to its TTL
+		if e.Method == http.MethodPut {
+			return ErrInvalidTTL
+		}
+	}
+
+	return nil
+}
+
+// ConnectionError is returned when a request could not be sent to the container,
+// or a response could not be read. It wraps the underlying error, allowing a
+// cancelled or expired context to be identified using errors.Is
+type ConnectionError struct {
+	Err error
+}
+
+// Error returns a formatted description of the error
+func (e *ConnectionError) Error() string {
+	return ErrConnection.Error() + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error matches ErrConnection
+func (e *ConnectionError) Is(target error) bool {
+	return target == ErrConnection
+}
@@ -23,8 +23,8 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
-	"net/http"
 	"time"
 
 	imds "github.com/purpleclay/testcontainers-imds"
@@ -66,12 +66,10 @@ func main() {
 
 func instanceID(container *imds.Container, token string) {
 	instanceID, status, err := container.GetV2(imds.PathInstanceID, token)
-	if err != nil {
-		log.Fatalf("Failed to query instance metadata mock. %s\n", err.Error())
-	}
-
-	if status == http.StatusUnauthorized {
+	if errors.Is(err, imds.ErrUnauthorized) {
 		instanceID = ""
+	} else if err != nil {
+		log.Fatalf("Failed to query instance metadata mock. %s\n", err.Error())
 	}
 
 	log.Printf("Retrieved instance ID: %s Status: %d\n", instanceID, status)
@@ -24,8 +24,8 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
-	"net/http"
 	"time"
 
 	imdsmock "github.com/purpleclay/imds-mock/pkg/imds"
@@ -58,16 +58,16 @@ func main() {
 	log.Println("IMDS mock started with spot interruption delayed for 5 seconds...")
 
 	for {
-		_, status, err := container.Get(imds.PathSpotInstanceAction)
-		if err != nil {
-			log.Fatalf("Failed to query instance metadata mock. %s\n", err.Error())
-		}
-
-		if status == http.StatusOK {
+		_, _, err := container.Get(imds.PathSpotInstanceAction)
+		if err == nil {
 			log.Println("Spot interruption detected. Exiting...")
 			break
 		}
 
+		if !errors.Is(err, imds.ErrCategoryNotFound) {
+			log.Fatalf("Failed to query instance metadata mock. %s\n", err.Error())
+		}
+
 		log.Println("No spot interruption detected. Sleeping for 1 second...")
 		time.Sleep(1 * time.Second)
 	}
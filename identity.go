@@ -0,0 +1,258 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	// Although archived, this remains the most widely used PKCS7 implementation and is
+	// stable. Maintained forks require a newer version of Go than this module supports
+	"go.mozilla.org/pkcs7"
+)
+
+const dynamicPrefix = "/latest/dynamic/"
+
+// gravitonInstanceType matches any instance type within an AWS Graviton (arm64) family,
+// such as m7g.large or c6gn.xlarge
+var gravitonInstanceType = regexp.MustCompile(`^[a-z]+[0-9]+g`)
+
+// IdentityDocument contains details about the instance, as returned by the
+// dynamic/instance-identity/document category. The document is signed by a test
+// certificate, available through IdentityCertificate()
+type IdentityDocument struct {
+	AccountID               string    `json:"accountId"`
+	Architecture            string    `json:"architecture"`
+	AvailabilityZone        string    `json:"availabilityZone"`
+	BillingProducts         []string  `json:"billingProducts"`
+	DevpayProductCodes      []string  `json:"devpayProductCodes"`
+	MarketplaceProductCodes []string  `json:"marketplaceProductCodes"`
+	ImageID                 string    `json:"imageId"`
+	InstanceID              string    `json:"instanceId"`
+	InstanceType            string    `json:"instanceType"`
+	KernelID                string    `json:"kernelId,omitempty"`
+	PendingTime             time.Time `json:"pendingTime"`
+	PrivateIP               string    `json:"privateIp"`
+	RamdiskID               string    `json:"ramdiskId,omitempty"`
+	Region                  string    `json:"region"`
+	Version                 string    `json:"version"`
+}
+
+// identity signs the instance identity document using a self-signed test certificate
+type identity struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+func newIdentity() (*identity, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: "imds-mock test certificate", Organization: []string{"testcontainers-imds"}},
+		NotBefore:             now.Add(-1 * time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &identity{cert: cert, key: key}, nil
+}
+
+// sign the identity document, returning the data served by each of the instance
+// identity categories, keyed by their path
+func (i *identity) sign(document []byte) (map[string][]byte, error) {
+	digest := sha256.Sum256(document)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	signedData, err := pkcs7.NewSignedData(document)
+	if err != nil {
+		return nil, err
+	}
+	signedData.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+
+	if err := signedData.AddSigner(i.cert, i.key, pkcs7.SignerInfoConfig{}); err != nil {
+		return nil, err
+	}
+
+	// Just like IMDS, the document must be provided separately during verification
+	signedData.Detach()
+
+	p7, err := signedData.Finish()
+	if err != nil {
+		return nil, err
+	}
+
+	// Both categories serve a PKCS7 signature, as the test certificate uses a 2048-bit
+	// RSA key. The agents of some AWS services only ever fetch rsa2048
+	encodedP7 := []byte(wrap(base64.StdEncoding.EncodeToString(p7), 64))
+
+	return map[string][]byte{
+		dynamicPrefix + DynamicPathInstanceIdentityDocument:  document,
+		dynamicPrefix + DynamicPathInstanceIdentitySignature: []byte(wrap(base64.StdEncoding.EncodeToString(signature), 64)),
+		dynamicPrefix + DynamicPathInstanceIdentityPKCS7:     encodedP7,
+		dynamicPrefix + DynamicPathInstanceIdentityRSA2048:   encodedP7,
+	}, nil
+}
+
+func wrap(s string, width int) string {
+	var lines []string
+	for len(s) > width {
+		lines = append(lines, s[:width])
+		s = s[width:]
+	}
+
+	return strings.Join(append(lines, s), "\n")
+}
+
+// identityDocument resolves an identity document from the instance metadata served
+// by the container, ensuring both remain consistent. Missing categories are ignored
+func (c *client) identityDocument(ctx context.Context) ([]byte, error) {
+	// Use a short-lived session token to support containers that enforce IMDSv2
+	resolver := newClient(c.endpoint)
+	resolver.httpClient = c.httpClient
+	resolver.token = newSessionToken(time.Minute)
+
+	values := map[string]string{}
+	for _, category := range []string{
+		PathAMIID,
+		PathInstanceID,
+		PathInstanceType,
+		PathLocalIPv4,
+		PathMAC,
+		PathPlacementAvailabilityZone,
+		PathPlacementRegion,
+	} {
+		value, err := resolver.value(ctx, category)
+		if err != nil && !errors.Is(err, ErrCategoryNotFound) {
+			return nil, err
+		}
+		values[category] = value
+	}
+
+	accountID, err := resolver.value(ctx, NetworkInterfacePath(values[PathMAC], InterfaceFieldOwnerID))
+	if err != nil && !errors.Is(err, ErrCategoryNotFound) {
+		return nil, err
+	}
+
+	architecture := "x86_64"
+	if gravitonInstanceType.MatchString(values[PathInstanceType]) {
+		architecture = "arm64"
+	}
+
+	return json.MarshalIndent(IdentityDocument{
+		AccountID:        accountID,
+		Architecture:     architecture,
+		AvailabilityZone: values[PathPlacementAvailabilityZone],
+		ImageID:          values[PathAMIID],
+		InstanceID:       values[PathInstanceID],
+		InstanceType:     values[PathInstanceType],
+		PendingTime:      time.Now().UTC().Truncate(time.Second),
+		PrivateIP:        values[PathLocalIPv4],
+		Region:           values[PathPlacementRegion],
+		Version:          "2017-09-30",
+	}, "", "  ")
+}
+
+// serveInstanceIdentity signs an identity document for the container and serves it,
+// along with its signatures, through the overlay
+func (c *client) serveInstanceIdentity(ctx context.Context) error {
+	var err error
+	if c.identity, err = newIdentity(); err != nil {
+		return err
+	}
+
+	document, err := c.identityDocument(ctx)
+	if err != nil {
+		return err
+	}
+
+	signed, err := c.identity.sign(document)
+	if err != nil {
+		return err
+	}
+
+	for path, data := range signed {
+		c.overlay.setRaw(path, data)
+	}
+
+	return nil
+}
+
+// IdentityDocument retrieves and decodes the instance identity document. Only available
+// if the container was started with InstanceIdentity. The document is signed at startup
+// and does not reflect any changes made to the instance metadata at runtime
+func (c *client) IdentityDocument(ctx context.Context) (IdentityDocument, error) {
+	out, _, err := c.GetDynamicContext(ctx, DynamicPathInstanceIdentityDocument)
+	if err != nil {
+		return IdentityDocument{}, err
+	}
+
+	var document IdentityDocument
+	if err := json.Unmarshal([]byte(out), &document); err != nil {
+		return IdentityDocument{}, err
+	}
+
+	return document, nil
+}
+
+// IdentityCertificate returns the test certificate used to sign the instance identity
+// document. It can be used to verify any of the signatures served by the container.
+// Only available if the container was started with InstanceIdentity
+func (c *client) IdentityCertificate() *x509.Certificate {
+	if c.identity == nil {
+		return nil
+	}
+
+	return c.identity.cert
+}
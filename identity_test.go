@@ -0,0 +1,112 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mozilla.org/pkcs7"
+)
+
+func TestIdentityDocument(t *testing.T) {
+	container := startWithOptions(t, imds.Options{
+		InstanceIdentity: true,
+		Overrides: map[string]string{
+			imds.PathInstanceType:    "m7g.large",
+			imds.PathPlacementRegion: "eu-west-2",
+		},
+	})
+
+	document, err := container.IdentityDocument(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, imds.ValueNetworkInterfaces0OwnerID, document.AccountID)
+	assert.Equal(t, "arm64", document.Architecture)
+	assert.Equal(t, imds.ValuePlacementAvailabilityZone, document.AvailabilityZone)
+	assert.Equal(t, imds.ValueAMIID, document.ImageID)
+	assert.Equal(t, imds.ValueInstanceID, document.InstanceID)
+	assert.Equal(t, "m7g.large", document.InstanceType)
+	assert.Equal(t, imds.ValueLocalIPv4, document.PrivateIP)
+	assert.Equal(t, "eu-west-2", document.Region)
+	assert.False(t, document.PendingTime.IsZero())
+}
+
+func TestIdentityDocument_Signature(t *testing.T) {
+	container := startWithOptions(t, imds.Options{InstanceIdentity: true})
+
+	document, _, err := container.GetDynamic(imds.DynamicPathInstanceIdentityDocument)
+	require.NoError(t, err)
+
+	encoded, _, err := container.GetDynamic(imds.DynamicPathInstanceIdentitySignature)
+	require.NoError(t, err)
+
+	signature, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\n", ""))
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte(document))
+	publicKey := container.IdentityCertificate().PublicKey.(*rsa.PublicKey)
+	assert.NoError(t, rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature))
+}
+
+func TestIdentityDocument_PKCS7(t *testing.T) {
+	container := startWithOptions(t, imds.Options{InstanceIdentity: true})
+
+	document, _, err := container.GetDynamic(imds.DynamicPathInstanceIdentityDocument)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(container.IdentityCertificate())
+
+	for _, category := range []string{imds.DynamicPathInstanceIdentityPKCS7, imds.DynamicPathInstanceIdentityRSA2048} {
+		encoded, _, err := container.GetDynamic(category)
+		require.NoError(t, err)
+
+		der, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\n", ""))
+		require.NoError(t, err)
+
+		p7, err := pkcs7.Parse(der)
+		require.NoError(t, err)
+
+		p7.Content = []byte(document)
+		assert.NoError(t, p7.VerifyWithChain(pool))
+	}
+}
+
+func TestIdentityDocument_NotEnabled(t *testing.T) {
+	container := startWithDefaults(t)
+
+	_, err := container.IdentityDocument(context.Background())
+
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+	assert.Nil(t, container.IdentityCertificate())
+}
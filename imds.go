@@ -24,15 +24,17 @@ package imds
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
-	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/creasty/defaults"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
 	imdsmock "github.com/purpleclay/imds-mock/pkg/imds"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -50,13 +52,27 @@ const (
 	AllCategories = ""
 )
 
+const (
+	// containerPort is the default port exposed by the imds-mock container
+	containerPort = "1338/tcp"
+
+	// linkLocalPort is the port used by the imds-mock container when it is reachable
+	// through either the link-local or IPv6 address, matching the real IMDS
+	linkLocalPort = "80/tcp"
+
+	// defaultRequestTimeout bounds any request that is not issued with a
+	// caller provided context
+	defaultRequestTimeout = 1 * time.Second
+)
+
 // Container represents an instance of an AEMM container
 type Container struct {
 	testcontainers.Container
+	*client
 
-	metadataURL string
-	tokenURL    string
-	client      *http.Client
+	proxy     *http.Server
+	linkLocal bool
+	ipv6      bool
 }
 
 // Start will create and start an instance of the Instance Metadata Mock (imds-mock),
@@ -66,6 +82,9 @@ type Container struct {
 //
 // http://localhost:1338/latest/meta-data/
 //
+// A Docker container is always started, ignoring both the InProcess option and the
+// IMDS_IN_PROCESS environment variable. Use New() to select the backend at runtime.
+//
 // By using the default settings, both IMDSv1 and IMDSv2 are supported. Metadata about the
 // mocked EC2 instance can then be retrieved using any of the documented categories,
 // https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/instancedata-data-categories.html
@@ -75,15 +94,18 @@ type Container struct {
 //	curl http://localhost:1338/latest/meta-data/local-ipv4
 //	curl http://localhost:1338/latest/meta-data/block-device-mapping/root
 //
-// To ensure your AWS config is configured to call this mock, the required option needs to
-// be set:
+// To ensure your AWS config is configured to call this mock, the required options can be
+// generated using the imdsaws package:
 //
 //	package main
 //
-//	import "github.com/aws/aws-sdk-go-v2/config"
+//	import (
+//		"github.com/aws/aws-sdk-go-v2/config"
+//		"github.com/purpleclay/testcontainers-imds/imdsaws"
+//	)
 //
 //	func main() {
-//		config.LoadDefaultConfig(context.TODO(), config.WithEC2IMDSEndpoint("http://localhost:1338/latest/meta-data/"))
+//		config.LoadDefaultConfig(context.TODO(), imdsaws.LoadOptions(container, imdsaws.Options{})...)
 //	}
 func Start(ctx context.Context) (*Container, error) {
 	return StartWith(ctx, Options{})
@@ -112,8 +134,8 @@ type Options struct {
 	// 	@Default false
 	ExcludeInstanceTags bool
 
-	// ExposedPort defines which port on the host will be mapped to the default port
-	// of the container
+	// ExposedPort defines which port on the host the proxy serving the instance
+	// metadata of the container will bind to
 	//	@Default 1338
 	ExposedPort string `default:"1338"`
 
@@ -136,6 +158,12 @@ type Options struct {
 	//	@Default false
 	Pretty bool
 
+	// RandomPort binds the proxy serving the instance metadata of the container to an
+	// ephemeral port on the host. Enable this to safely run multiple containers in
+	// parallel. ExposedPort is ignored when this is set
+	//	@Default false
+	RandomPort bool
+
 	// Spot is a flag that controls the simulation of a spot instance and interruption
 	// notice
 	//	@Default false
@@ -144,7 +172,36 @@ type Options struct {
 	// SpotAction is used in conjunction with the spot flag to control both the type
 	// and initial delay of the spot interruption notice.
 	//   @Default
-	SpotAction imdsmock.SpotActionEvent `default:"{\"Action\":\"terminate\", \"Duration\": \"0s\"}"`
+	SpotAction imdsmock.SpotActionEvent `default:"{\"Action\":\"terminate\", \"Duration\": 0}"`
+
+	// Rebalance is a flag that controls the simulation of a rebalance recommendation
+	// signal, exposed through the events/recommendations/rebalance category. It is
+	// independent of Spot, and replaces any recommendation raised by the mock
+	//	@Default false
+	Rebalance bool
+
+	// RebalanceDelay is used in conjunction with the rebalance flag to control the
+	// initial delay before the rebalance recommendation appears. The noticeTime of the
+	// recommendation reflects when it appeared
+	//	@Default 0
+	RebalanceDelay time.Duration
+
+	// Autoscaling is a flag that controls the simulation of an instance within an Auto
+	// Scaling group, exposing the autoscaling/target-lifecycle-state category. This is
+	// not supported by the mock and is served through the same proxy used by Overrides
+	//	@Default false
+	Autoscaling bool
+
+	// TargetLifecycleState is used in conjunction with the autoscaling flag to control
+	// the initial target lifecycle state of the instance
+	//	@Default InService
+	TargetLifecycleState TargetLifecycleState `default:"InService"`
+
+	// Scenario is played against the instance metadata at startup, changing it as its
+	// virtual clock is advanced. The clock is retrieved through Scenario(). Providing a
+	// scenario implies Mutable
+	//	@Default nil
+	Scenario *Scenario
 
 	// IMDSv2 will enforce IMDSv2 and require a session token when making metadata
 	// requests. A token is requested by issuing a PUT request to the token endpoint, and
@@ -158,6 +215,184 @@ type Options struct {
 	//
 	//	@Default false
 	IMDSv2 bool
+
+	// ManageToken enables the transparent management of IMDSv2 session tokens when
+	// retrieving instance categories through either Get() or GetContext(). A session
+	// token is fetched on first use, cached and then refreshed before it expires. If
+	// a request is rejected as unauthorized, it will be retried once with a newly
+	// issued session token
+	//	@Default false
+	ManageToken bool
+
+	// TokenTTL defines the TTL of any session token fetched when ManageToken is
+	// enabled. It must be between 1 and 21600 seconds, once truncated to whole seconds,
+	// otherwise ErrInvalidTTL is returned at startup
+	//	@Default 6h
+	TokenTTL time.Duration `default:"6h"`
+
+	// Overrides defines a set of instance categories that should be served in place of
+	// any existing defaults. Each category is identified by its path, and will be added
+	// if it does not already exist:
+	//
+	//	imds.Options{
+	//		Overrides: map[string]string{
+	//			imds.PathInstanceID:      "i-0123456789abcdef0",
+	//			imds.PathInstanceType:    "m7g.large",
+	//			imds.PathPlacementRegion: "eu-west-2",
+	//		},
+	//	}
+	//
+	// As the mock has no native support for overrides, they are served through a proxy
+	// on the host that sits in front of the container. The proxy binds to ExposedPort
+	// (or an ephemeral port if RandomPort is set) and is accessible through URL().
+	// Session tokens and IMDSv2 enforcement are still handled by the container.
+	//
+	// Overrides only apply to requests made through URL(). Any other container reaching
+	// the mock directly will always see its built-in defaults, and for this reason they
+	// cannot be combined with Network
+	//	@Default no overrides
+	Overrides map[string]string
+
+	// Document provides a complete instance metadata document, in either JSON or YAML,
+	// that is served in place of the built-in defaults. Categories are nested objects
+	// keyed by name, mirroring the paths of the metadata endpoint:
+	//
+	//	instance-id: i-0123456789abcdef0
+	//	placement:
+	//	  region: eu-west-2
+	//	security-groups: [web, db]
+	//
+	// A list of values, such as security-groups, is served one value per line. Only
+	// categories that contain a JSON document, such as iam/info, are served as JSON.
+	//
+	// As the document replaces all built-in defaults, both InstanceTags and Spot will
+	// have no effect on the instance metadata. Overrides are applied on top of the
+	// document. The document is served through the same proxy used by Overrides, and
+	// in the same way, only applies to requests made through URL(). For this reason,
+	// it cannot be combined with Network
+	//	@Default built-in defaults
+	Document io.Reader
+
+	// DocumentFile is the path to an instance metadata document on the host. It behaves
+	// in the same way as Document, which takes precedence if both are provided
+	//	@Default built-in defaults
+	DocumentFile string
+
+	// UserData is served verbatim through the user-data endpoint, supporting any format,
+	// including gzip compressed or MIME multipart cloud-init payloads. The user data is
+	// served through the same proxy used by Overrides
+	//
+	//	http://localhost:1338/latest/user-data
+	//
+	//	@Default no user data
+	UserData []byte
+
+	// InstanceIdentity enables the dynamic data endpoint, serving an instance identity
+	// document that is consistent with the instance metadata of the container. The
+	// document is signed using a self-signed test certificate, generated at startup and
+	// available through IdentityCertificate(). Dynamic data is served through the same
+	// proxy used by Overrides.
+	//
+	// The document is signed once at startup, and is not signed again if the instance
+	// metadata is changed at runtime. Both the pkcs7 and rsa2048 categories serve the
+	// same PKCS7 signature, as the test certificate uses a 2048-bit RSA key
+	//
+	//	http://localhost:1338/latest/dynamic/instance-identity/document
+	//
+	//	@Default false
+	InstanceIdentity bool
+
+	// IAMRole defines the name of the IAM role associated with the instance, which is
+	// served through the iam/security-credentials category. Any other name is served
+	// through the same proxy used by Overrides
+	//	@Default ssm-access
+	IAMRole string `default:"ssm-access"`
+
+	// CredentialsLifetime enables the generation of temporary security credentials for
+	// the IAM role, each valid for the given lifetime. Credentials are rotated at the end
+	// of each lifetime, changing both the AccessKeyId and Token, supporting the testing
+	// of any credential refresh logic. Generated credentials are served through the
+	// same proxy used by Overrides, replacing the expired credentials of the mock. Any
+	// credentials within a Document are served verbatim, unless either IAMRole or
+	// CredentialsLifetime is set
+	//	@Default 6h
+	CredentialsLifetime time.Duration
+
+	// NetworkInterfaces defines the elastic network interfaces (ENIs) attached to the
+	// instance, replacing the single interface of the mock entirely. The primary
+	// interface, with a device number of 0, also defines the mac, local-ipv4 and
+	// public-ipv4 categories. Network interfaces are served through the same proxy
+	// used by Overrides
+	//
+	//	imds.Options{
+	//		NetworkInterfaces: []imds.NetworkInterface{
+	//			{MAC: "0a:1b:2c:3d:4e:5f", PrivateIPv4s: []string{"10.0.1.10"}},
+	//			{MAC: "0a:1b:2c:3d:4e:60", DeviceNumber: 1, PrivateIPv4s: []string{"10.0.2.10"}},
+	//		},
+	//	}
+	//
+	//	@Default the single network interface of the mock
+	NetworkInterfaces []NetworkInterface
+
+	// Mutable enables the instance metadata to be changed at runtime, through SetValue,
+	// SetTags, Delete, TriggerSpotInterruption, TriggerRebalanceRecommendation,
+	// ScheduleMaintenanceEvent and SetTargetLifecycleState. Changes are served through
+	// the same proxy used by Overrides
+	//	@Default false
+	Mutable bool
+
+	// InProcess selects an in-process Server in place of a Docker container when
+	// starting the imds-mock through New(). It can also be enabled by setting the
+	// IMDS_IN_PROCESS environment variable to true, switching backends without any
+	// code changes. Both are ignored by Start() and StartWith(), which always start a
+	// Docker container, so callers must move to New() to switch backends
+	//	@Default false
+	InProcess bool
+
+	// Network is the name of an existing Docker network that the container will join,
+	// making it reachable from any sibling container on the same network. This is
+	// ignored by an in-process Server.
+	//
+	// Sibling containers communicate directly with the imds-mock, and not through the
+	// proxy on the host. Any option served by the proxy would not be visible to them,
+	// and cannot be combined with Network: Overrides, Document, DocumentFile, UserData,
+	// InstanceIdentity, IAMRole, CredentialsLifetime, NetworkInterfaces, Rebalance,
+	// Autoscaling, Mutable and Scenario. Sibling containers are also served the static
+	// credentials of the mock, which have already expired
+	//	@Default the default bridge network
+	Network string
+
+	// NetworkAliases defines a list of hostnames that the container can be reached
+	// through from within the Network
+	//	@Default no aliases
+	NetworkAliases []string
+
+	// LinkLocal assigns the link-local address 169.254.169.254 to the container within
+	// the Network, and serves the imds-mock on port 80. Any unmodified AWS SDK running
+	// within a sibling container will then discover it without an endpoint override.
+	// The Network must have a subnet that includes the link-local address, such as one
+	// created by NewLinkLocalNetwork().
+	//
+	//	http://169.254.169.254/latest/meta-data/
+	//
+	// As sibling containers communicate directly with the imds-mock, IMDSv2 is not
+	// supported, as the session tokens issued by the imds-mock cannot be parsed by an
+	// unmodified AWS SDK
+	//	@Default false
+	LinkLocal bool
+
+	// IPv6 assigns the IPv6 address fd00:ec2::254 to the container within the Network,
+	// and serves the imds-mock on port 80, simulating the IPv6 endpoint of IMDS on Nitro
+	// instances. The Network must have IPv6 enabled and a subnet that includes the
+	// address, such as one created by NewIPv6Network(). Any AWS SDK running within a
+	// sibling container can select it by setting AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE
+	// to IPv6.
+	//
+	//	http://[fd00:ec2::254]/latest/meta-data/
+	//
+	// As with LinkLocal, IMDSv2 is not supported
+	//	@Default false
+	IPv6 bool
 }
 
 // StartWith will create and start an instance of the Instance Metadata Mock (imds-mock),
@@ -168,6 +403,9 @@ type Options struct {
 //
 // http://localhost:1338/latest/meta-data/
 //
+// A Docker container is always started, ignoring both the InProcess option and the
+// IMDS_IN_PROCESS environment variable. Use New() to select the backend at runtime.
+//
 // By using the default settings, both IMDSv1 and IMDSv2 are supported. Metadata about the
 // mocked EC2 instance can then be retrieved using any of the documented categories,
 // https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/instancedata-data-categories.html
@@ -177,22 +415,63 @@ type Options struct {
 //	curl http://localhost:1338/latest/meta-data/local-ipv4
 //	curl http://localhost:1338/latest/meta-data/block-device-mapping/root
 //
-// To ensure your AWS config is configured to call this mock, the required option needs to
-// be set:
+// To ensure your AWS config is configured to call this mock, the required options can be
+// generated using the imdsaws package:
 //
 //	package main
 //
-//	import "github.com/aws/aws-sdk-go-v2/config"
+//	import (
+//		"github.com/aws/aws-sdk-go-v2/config"
+//		"github.com/purpleclay/testcontainers-imds/imdsaws"
+//	)
 //
 //	func main() {
-//		config.LoadDefaultConfig(context.TODO(), config.WithEC2IMDSEndpoint("http://localhost:1338/latest/meta-data/"))
+//		config.LoadDefaultConfig(context.TODO(), imdsaws.LoadOptions(container, imdsaws.Options{})...)
 //	}
 func StartWith(ctx context.Context, opts Options) (*Container, error) {
+	if opts.LinkLocal && opts.Network == "" {
+		return nil, errors.New("link-local address can only be assigned within a network")
+	}
+
+	if opts.IPv6 && opts.Network == "" {
+		return nil, errors.New("IPv6 address can only be assigned within a network")
+	}
+
+	// Sibling containers reach the mock directly, which omits the TTL header of a session
+	// token. Any unmodified AWS SDK would reject the token and fail to retrieve metadata
+	if opts.LinkLocal && opts.IMDSv2 {
+		return nil, errors.New("IMDSv2 is not supported on the link-local address")
+	}
+
+	if opts.IPv6 && opts.IMDSv2 {
+		return nil, errors.New("IMDSv2 is not supported on the IPv6 address")
+	}
+
+	if options := proxyOnlyOptions(opts); len(options) > 0 && opts.Network != "" {
+		return nil, fmt.Errorf("%s only served through URL() and cannot be combined with a network",
+			strings.Join(options, ", "))
+	}
+
+	if err := validateNetworkInterfaces(opts.NetworkInterfaces); err != nil {
+		return nil, err
+	}
+
+	mockPort := nat.Port(containerPort)
+	if opts.LinkLocal || opts.IPv6 {
+		mockPort = linkLocalPort
+	}
+
 	// Adjust the wait strategy based on the options
-	waitStrategy := wait.ForHTTP("/latest/meta-data/").WithPort("1338")
+	waitStrategy := wait.ForHTTP("/latest/meta-data/").WithPort(mockPort)
 
 	// Ensure all defaults are set before launching the container
-	defaults.Set(&opts)
+	if err := defaults.Set(&opts); err != nil {
+		return nil, err
+	}
+
+	if err := validateTokenTTL(opts.TokenTTL); err != nil {
+		return nil, err
+	}
 
 	var flags []string
 	if opts.ExcludeInstanceTags {
@@ -219,17 +498,48 @@ func StartWith(ctx context.Context, opts Options) (*Container, error) {
 
 		// 401 should be issued without a token
 		waitStrategy = wait.ForHTTP("/latest/meta-data/").
-			WithPort("1338").
+			WithPort(mockPort).
 			WithStatusCodeMatcher(func(status int) bool { return status == http.StatusUnauthorized })
 	}
 
+	if opts.LinkLocal || opts.IPv6 {
+		flags = append(flags, "--port", mockPort.Port())
+	}
+
+	document, err := readDocumentOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The overlay proxy takes ownership of the exposed port, leaving Docker to allocate
+	// a random port for the container
 	req := testcontainers.ContainerRequest{
 		Image:        fmt.Sprintf("%s:%s", opts.Image, opts.ImageTag),
 		Cmd:          flags,
-		ExposedPorts: []string{opts.ExposedPort + ":1338/tcp"},
+		ExposedPorts: []string{string(mockPort)},
 		WaitingFor:   waitStrategy,
 	}
 
+	if opts.Network != "" {
+		req.Networks = []string{opts.Network}
+		req.NetworkAliases = map[string][]string{opts.Network: opts.NetworkAliases}
+	}
+
+	if opts.LinkLocal || opts.IPv6 {
+		req.EnpointSettingsModifier = func(settings map[string]*network.EndpointSettings) {
+			if endpoint, ok := settings[opts.Network]; ok {
+				endpoint.IPAMConfig = &network.EndpointIPAMConfig{}
+				if opts.LinkLocal {
+					endpoint.IPAMConfig.IPv4Address = LinkLocalAddress
+				}
+
+				if opts.IPv6 {
+					endpoint.IPAMConfig.IPv6Address = IPv6Address
+				}
+			}
+		}
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: req,
 		Started:          true,
@@ -238,12 +548,64 @@ func StartWith(ctx context.Context, opts Options) (*Container, error) {
 		return nil, err
 	}
 
-	return &Container{
-		Container:   container,
-		metadataURL: fmt.Sprintf("http://localhost:%s/latest/meta-data/", opts.ExposedPort),
-		tokenURL:    fmt.Sprintf("http://localhost:%s/latest/api/token", opts.ExposedPort),
-		client:      &http.Client{Timeout: 1 * time.Second},
-	}, nil
+	// Resolve the real address of the container, as the host port may have been
+	// allocated by Docker
+	host, err := container.Host(ctx)
+	if err != nil {
+		container.Terminate(ctx)
+		return nil, err
+	}
+
+	port, err := container.MappedPort(ctx, mockPort)
+	if err != nil {
+		container.Terminate(ctx)
+		return nil, err
+	}
+
+	endpoint := "http://" + net.JoinHostPort(host, port.Port())
+
+	imdsContainer := &Container{
+		Container: container,
+		client:    newClient(endpoint),
+		linkLocal: opts.LinkLocal,
+		ipv6:      opts.IPv6,
+	}
+
+	if imdsContainer.proxy, err = imdsContainer.startOverlay(ctx, reverseProxy(endpoint), document, opts); err != nil {
+		container.Terminate(ctx)
+		return nil, err
+	}
+
+	if opts.ManageToken {
+		imdsContainer.token = newSessionToken(opts.TokenTTL)
+	}
+
+	return imdsContainer, nil
+}
+
+// proxyOnlyOptions returns the name of every option that is only served through the
+// proxy on the host, and would not be visible to any sibling container
+func proxyOnlyOptions(opts Options) []string {
+	var options []string
+	add := func(set bool, name string) {
+		if set {
+			options = append(options, name)
+		}
+	}
+
+	add(len(opts.Overrides) > 0, "Overrides")
+	add(opts.Document != nil || opts.DocumentFile != "", "Document")
+	add(opts.UserData != nil, "UserData")
+	add(opts.InstanceIdentity, "InstanceIdentity")
+	add(opts.IAMRole != "" && opts.IAMRole != DefaultIAMRole, "IAMRole")
+	add(opts.CredentialsLifetime > 0, "CredentialsLifetime")
+	add(len(opts.NetworkInterfaces) > 0, "NetworkInterfaces")
+	add(opts.Rebalance, "Rebalance")
+	add(opts.Autoscaling, "Autoscaling")
+	add(opts.Mutable, "Mutable")
+	add(opts.Scenario != nil, "Scenario")
+
+	return options
 }
 
 func keyValueListFlag(in map[string]string) string {
@@ -274,75 +636,19 @@ func MustStartWith(ctx context.Context, opts Options) *Container {
 	return container
 }
 
-// URL returns the URL for accessing the metadata endpoint of the container
-//
-//	http://localhost:<EXPOSED_PORT>/latest/meta-data/
-func (c *Container) URL() string {
-	return c.metadataURL
-}
-
-// TokenURL returns the URL for accessing the token endpoint of the container
-//
-//	http://localhost:<EXPOSED_PORT>/latest/api/token
-func (c *Container) TokenURL() string {
-	return c.tokenURL
-}
-
-// Get will attempt to retrieve an instance category from the running container. The raw
-// value of the category will be returned from the container upon success. If any HTTP
-// failure occurs while trying to retrieve a category, the raw error is returned
-//
-// Status Codes:
-//
-//	200: category was retrieved
-//	404: category does not exist
-func (c *Container) Get(category string) (string, int, error) {
-	return c.GetV2(category, "")
-}
-
-// GetV2 will attempt to retrieve an instance category from the running container
-// using an authenticated session token based request. If the container was not started
-// in IMDSv2 mode, the token will have no effect. The raw value of the category will
-// be returned from the container upon success. If any HTTP failure occurs while trying
-// to retrieve a category, the raw error is returned
-//
-// Status Codes:
-//   - 200: category was retrieved
-//   - 404: category does not exist
-//   - 401: session token is either invalid or expired
-func (c *Container) GetV2(category, token string) (string, int, error) {
-	categoryURL, _ := url.JoinPath(c.metadataURL, category)
-
-	req, _ := http.NewRequest(http.MethodGet, categoryURL, http.NoBody)
-	if token != "" {
-		req.Header.Add("X-aws-ec2-metadata-token", token)
-	}
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return "", 0, err
+// Terminate will stop and remove the container, along with the proxy on the host that
+// serves its instance metadata
+func (c *Container) Terminate(ctx context.Context) error {
+	c.cancelRebalanceRecommendation()
+	if c.proxy != nil {
+		c.proxy.Close()
 	}
 
-	data, _ := io.ReadAll(resp.Body)
-	return string(data), resp.StatusCode, resp.Body.Close()
+	return c.Container.Terminate(ctx)
 }
 
-// TokenWithTTL will attempt to generate a session token with the provided TTL in seconds.
-// If any HTTP failure occurs while trying to retrieve a category, the raw error is returned
-//
-// Status Codes:
-//
-//	200: token was created
-//	400: TTL was outside the expected bounds (min: 1, max: 21600)
-func (c *Container) TokenWithTTL(ttl int) (string, int, error) {
-	req, _ := http.NewRequest(http.MethodPut, c.tokenURL, http.NoBody)
-	req.Header.Add("X-aws-ec2-metadata-token-ttl-seconds", strconv.Itoa(ttl))
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return "", 0, err
-	}
-
-	data, _ := io.ReadAll(resp.Body)
-	return string(data), resp.StatusCode, resp.Body.Close()
+// Close will stop and remove the container, along with the proxy on the host that
+// serves its instance metadata
+func (c *Container) Close() error {
+	return c.Terminate(context.Background())
 }
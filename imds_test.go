@@ -26,6 +26,8 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -95,6 +97,19 @@ func TestStartWith_ExposedPort(t *testing.T) {
 	assert.Contains(t, string(out), "local-ipv4")
 }
 
+func TestStartWith_RandomPort(t *testing.T) {
+	container1 := startWithOptions(t, imds.Options{RandomPort: true})
+	container2 := startWithOptions(t, imds.Options{RandomPort: true})
+
+	require.NotEqual(t, container1.URL(), container2.URL())
+
+	out, _ := get(t, container1.URL())
+	assert.Contains(t, out, "local-ipv4")
+
+	out, _ = get(t, container2.URL())
+	assert.Contains(t, out, "local-ipv4")
+}
+
 func TestStartWith_Pretty(t *testing.T) {
 	startWithOptions(t, imds.Options{Pretty: true})
 
@@ -157,6 +172,96 @@ func TestStartWith_SpotAction(t *testing.T) {
 	assert.Contains(t, out, `"action":"stop"`)
 }
 
+func TestStartWith_Overrides(t *testing.T) {
+	container := startWithOptions(t, imds.Options{
+		Overrides: map[string]string{
+			imds.PathInstanceID:      "i-0123456789abcdef0",
+			imds.PathInstanceType:    "m7g.large",
+			imds.PathPlacementRegion: "eu-west-2",
+			"placement/group-name":   "graviton",
+		},
+	})
+
+	out, _ := get(t, "http://localhost:1338/latest/meta-data/instance-id")
+	assert.Equal(t, "i-0123456789abcdef0", out)
+
+	out, _ = get(t, "http://localhost:1338/latest/meta-data/instance-type")
+	assert.Equal(t, "m7g.large", out)
+
+	region, err := container.Region(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-2", region)
+
+	out, _ = get(t, "http://localhost:1338/latest/meta-data/placement/")
+	placement := strings.Split(out, "\n")
+	assert.Contains(t, placement, "group-name")
+	assert.Contains(t, placement, "region")
+}
+
+func TestStartWith_OverridesIMDSv2(t *testing.T) {
+	startWithOptions(t, imds.Options{
+		IMDSv2:    true,
+		Overrides: map[string]string{imds.PathInstanceID: "i-0123456789abcdef0"},
+	})
+
+	_, status := get(t, "http://localhost:1338/latest/meta-data/instance-id")
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	token, _ := getToken(t, "http://localhost:1338/latest/api/token")
+	out, status := getWithToken(t, "http://localhost:1338/latest/meta-data/instance-id", token)
+
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "i-0123456789abcdef0", out)
+}
+
+func TestStartWith_Document(t *testing.T) {
+	startWithOptions(t, imds.Options{
+		Document: strings.NewReader(`
+instance-id: i-0123456789abcdef0
+instance-type: m7g.large
+placement:
+  region: eu-west-2
+`),
+	})
+
+	out, _ := get(t, "http://localhost:1338/latest/meta-data/")
+	assert.Equal(t, "instance-id\ninstance-type\nplacement/", out)
+
+	out, _ = get(t, "http://localhost:1338/latest/meta-data/placement/region")
+	assert.Equal(t, "eu-west-2", out)
+
+	// Built-in defaults are no longer served
+	_, status := get(t, "http://localhost:1338/latest/meta-data/ami-id")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestStartWith_DocumentFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metadata.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+  "instance-id": "i-0123456789abcdef0",
+  "iam": {
+    "info": {
+      "Code": "Success",
+      "InstanceProfileArn": "arn:aws:iam::112233445566:instance-profile/testing"
+    }
+  }
+}`), 0o600))
+
+	startWithOptions(t, imds.Options{DocumentFile: path})
+
+	out, _ := get(t, "http://localhost:1338/latest/meta-data/instance-id")
+	assert.Equal(t, "i-0123456789abcdef0", out)
+
+	out, _ = get(t, "http://localhost:1338/latest/meta-data/iam/info")
+	assert.JSONEq(t, `{"Code":"Success","InstanceProfileArn":"arn:aws:iam::112233445566:instance-profile/testing"}`, out)
+}
+
+func TestStartWith_DocumentInvalid(t *testing.T) {
+	_, err := imds.StartWith(context.Background(), imds.Options{Document: strings.NewReader("{")})
+
+	require.Error(t, err)
+}
+
 func startWithDefaults(t *testing.T) *imds.Container {
 	t.Helper()
 
@@ -199,6 +304,27 @@ func get(t *testing.T, url string) (string, int) {
 	return string(out), resp.StatusCode
 }
 
+func getWithToken(t *testing.T, url, token string) (string, int) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	require.NoError(t, err)
+
+	req.Header.Add("X-aws-ec2-metadata-token", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		resp.Body.Close()
+	})
+
+	out, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(out), resp.StatusCode
+}
+
 func getToken(t *testing.T, url string) (string, int) {
 	t.Helper()
 
@@ -253,7 +379,52 @@ func TestGetTimeout(t *testing.T) {
 
 	assert.Empty(t, out)
 	assert.Equal(t, 0, status)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, imds.ErrConnection)
+}
+
+func TestGet_NotFound(t *testing.T) {
+	container := startWithDefaults(t)
+
+	_, status, err := container.Get("unknown")
+
+	assert.Equal(t, http.StatusNotFound, status)
+	require.ErrorIs(t, err, imds.ErrCategoryNotFound)
+
+	var statusErr *imds.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	assert.Contains(t, statusErr.Body, "<h1>404 - Not Found</h1>")
+}
+
+func TestStatusError_BadRequest(t *testing.T) {
+	tokenErr := &imds.StatusError{Method: http.MethodPut, StatusCode: http.StatusBadRequest}
+	assert.ErrorIs(t, tokenErr, imds.ErrInvalidTTL)
+
+	getErr := &imds.StatusError{Method: http.MethodGet, StatusCode: http.StatusBadRequest}
+	assert.NotErrorIs(t, getErr, imds.ErrInvalidTTL)
+}
+
+func TestGetContext(t *testing.T) {
+	container := startWithDefaults(t)
+
+	ipv4, status, err := container.GetContext(context.Background(), imds.PathLocalIPv4)
+
+	assert.Equal(t, imds.ValueLocalIPv4, ipv4)
+	assert.Equal(t, http.StatusOK, status)
+	assert.NoError(t, err)
+}
+
+func TestGetContextCancelled(t *testing.T) {
+	container := startWithDefaults(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	out, status, err := container.GetContext(ctx, imds.PathLocalIPv4)
+
+	assert.Empty(t, out)
+	assert.Equal(t, 0, status)
+	assert.ErrorIs(t, err, context.Canceled)
 }
 
 func TestGetV2(t *testing.T) {
@@ -269,6 +440,58 @@ func TestGetV2(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGet_ManageToken(t *testing.T) {
+	container := startWithOptions(t, imds.Options{IMDSv2: true, ManageToken: true})
+
+	amiID, status, err := container.Get(imds.PathAMIID)
+
+	assert.Equal(t, imds.ValueAMIID, amiID)
+	assert.Equal(t, http.StatusOK, status)
+	assert.NoError(t, err)
+}
+
+func TestGet_ManageTokenRefreshed(t *testing.T) {
+	container := startWithOptions(t, imds.Options{
+		IMDSv2:      true,
+		ManageToken: true,
+		TokenTTL:    1 * time.Second,
+	})
+
+	_, status, err := container.Get(imds.PathAMIID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, status)
+
+	// Allow the cached session token to expire
+	time.Sleep(1200 * time.Millisecond)
+
+	amiID, status, err := container.Get(imds.PathAMIID)
+
+	assert.Equal(t, imds.ValueAMIID, amiID)
+	assert.Equal(t, http.StatusOK, status)
+	assert.NoError(t, err)
+}
+
+func TestStartWith_InvalidTokenTTL(t *testing.T) {
+	for _, ttl := range []time.Duration{500 * time.Millisecond, 7 * time.Hour} {
+		_, err := imds.StartWith(context.Background(), imds.Options{
+			IMDSv2:      true,
+			ManageToken: true,
+			TokenTTL:    ttl,
+		})
+
+		assert.ErrorIs(t, err, imds.ErrInvalidTTL)
+	}
+}
+
+func TestGetV2_Unauthorized(t *testing.T) {
+	container := startWithOptions(t, imds.Options{IMDSv2: true})
+
+	_, status, err := container.GetV2(imds.PathAMIID, "")
+
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.ErrorIs(t, err, imds.ErrUnauthorized)
+}
+
 func TestTokenWithTTL(t *testing.T) {
 	container := startWithOptions(t, imds.Options{IMDSv2: true})
 
@@ -279,6 +502,19 @@ func TestTokenWithTTL(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestTokenWithTTLContextDeadlineExceeded(t *testing.T) {
+	container := startWithOptions(t, imds.Options{IMDSv2: true})
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-1*time.Second))
+	defer cancel()
+
+	out, status, err := container.TokenWithTTLContext(ctx, 10)
+
+	assert.Empty(t, out)
+	assert.Equal(t, 0, status)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestTokenWithTTLTimeout(t *testing.T) {
 	container := startWithDefaults(t)
 	// Ensure a connection error is simulated by immediately stopping the container
@@ -288,7 +524,16 @@ func TestTokenWithTTLTimeout(t *testing.T) {
 
 	assert.Empty(t, out)
 	assert.Equal(t, 0, status)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, imds.ErrConnection)
+}
+
+func TestTokenWithTTL_InvalidTTL(t *testing.T) {
+	container := startWithDefaults(t)
+
+	_, status, err := container.TokenWithTTL(imds.MaxTokenTTLInSeconds + 1)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.ErrorIs(t, err, imds.ErrInvalidTTL)
 }
 
 func TestURL(t *testing.T) {
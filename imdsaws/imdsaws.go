@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package imdsaws configures the AWS SDK for Go V2 to retrieve instance metadata from the
+// Instance Metadata Mock (imds-mock), regardless of how it is hosted. It removes the need
+// to manually derive the endpoint expected by the SDK from the URL of the mock:
+//
+//	cfg, err := config.LoadDefaultConfig(ctx, imdsaws.LoadOptions(container, imdsaws.Options{})...)
+//
+// It is released as a separate module, ensuring the AWS SDK is only required by those
+// that use it
+package imdsaws
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/ec2rolecreds"
+	ec2imds "github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	imds "github.com/purpleclay/testcontainers-imds"
+)
+
+// Options defines all configurable options when configuring the AWS SDK
+type Options struct {
+	// EndpointMode defines the endpoint mode of the SDK. As the endpoint is always
+	// resolved from the mock, this only affects how the SDK reports the mode, and
+	// should match the address family of URL()
+	//	@Default IPv4
+	EndpointMode ec2imds.EndpointModeState
+
+	// DisableIMDSv1Fallback prevents the SDK from falling back to IMDSv1 if a session
+	// token cannot be retrieved. Enable this to verify that a client is fully
+	// IMDSv2 compliant
+	//	@Default false
+	DisableIMDSv1Fallback bool
+}
+
+// Endpoint returns the endpoint of the mock in the format expected by the SDK, which
+// excludes the path to the metadata endpoint
+//
+//	http://localhost:1338
+func Endpoint(mock imds.IMDS) string {
+	return strings.TrimSuffix(mock.URL(), "/latest/meta-data/")
+}
+
+// ClientOptions returns a functional option that configures an EC2 IMDS client to
+// retrieve instance metadata from the mock
+//
+//	client := ec2imds.New(ec2imds.Options{}, imdsaws.ClientOptions(container, imdsaws.Options{}))
+func ClientOptions(mock imds.IMDS, opts Options) func(*ec2imds.Options) {
+	return func(o *ec2imds.Options) {
+		o.Endpoint = Endpoint(mock)
+		o.EndpointMode = endpointMode(opts)
+		o.ClientEnableState = ec2imds.ClientEnabled
+
+		if opts.DisableIMDSv1Fallback {
+			o.EnableFallback = aws.FalseTernary
+		}
+	}
+}
+
+// NewClient creates an EC2 IMDS client that retrieves instance metadata from the mock
+func NewClient(mock imds.IMDS, opts Options) *ec2imds.Client {
+	return ec2imds.New(ec2imds.Options{}, ClientOptions(mock, opts))
+}
+
+// LoadOptions returns a set of functional options for loading an AWS config that
+// retrieves both instance metadata and EC2 role credentials from the mock. Any
+// client created through ec2imds.NewFromConfig will also use the mock
+func LoadOptions(mock imds.IMDS, opts Options) []func(*config.LoadOptions) error {
+	return []func(*config.LoadOptions) error{
+		config.WithEC2IMDSEndpoint(Endpoint(mock)),
+		config.WithEC2IMDSEndpointMode(endpointMode(opts)),
+		config.WithEC2IMDSClientEnableState(ec2imds.ClientEnabled),
+		config.WithEC2RoleCredentialOptions(func(o *ec2rolecreds.Options) {
+			o.Client = NewClient(mock, opts)
+		}),
+	}
+}
+
+func endpointMode(opts Options) ec2imds.EndpointModeState {
+	if opts.EndpointMode == ec2imds.EndpointModeStateUnset {
+		return ec2imds.EndpointModeStateIPv4
+	}
+
+	return opts.EndpointMode
+}
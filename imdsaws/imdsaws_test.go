@@ -0,0 +1,152 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imdsaws_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/ec2rolecreds"
+	ec2imds "github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/purpleclay/testcontainers-imds/imdsaws"
+	"github.com/purpleclay/testcontainers-imds/imdstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoint(t *testing.T) {
+	server := startServer(t, imds.Options{ExposedPort: "1350"})
+
+	assert.Equal(t, "http://localhost:1350", imdsaws.Endpoint(server))
+}
+
+func TestNewClient(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+	client := imdsaws.NewClient(server, imdsaws.Options{})
+
+	assert.Equal(t, imds.ValueInstanceID, getMetadata(t, client, imds.PathInstanceID))
+}
+
+func TestNewClient_IMDSv2(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, IMDSv2: true})
+	client := imdsaws.NewClient(server, imdsaws.Options{DisableIMDSv1Fallback: true})
+
+	assert.Equal(t, imds.ValueInstanceID, getMetadata(t, client, imds.PathInstanceID))
+}
+
+func TestClientOptions_EndpointMode(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	var o ec2imds.Options
+	imdsaws.ClientOptions(server, imdsaws.Options{})(&o)
+	assert.Equal(t, ec2imds.EndpointModeStateIPv4, o.EndpointMode)
+
+	imdsaws.ClientOptions(server, imdsaws.Options{EndpointMode: ec2imds.EndpointModeStateIPv6})(&o)
+	assert.Equal(t, ec2imds.EndpointModeStateIPv6, o.EndpointMode)
+}
+
+func TestLoadOptions(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	cfg, err := config.LoadDefaultConfig(context.Background(), imdsaws.LoadOptions(server, imdsaws.Options{})...)
+	require.NoError(t, err)
+
+	client := ec2imds.NewFromConfig(cfg)
+	assert.Equal(t, imds.ValueInstanceType, getMetadata(t, client, imds.PathInstanceType))
+}
+
+func TestLoadOptions_EndpointMode(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	var o config.LoadOptions
+	for _, opt := range imdsaws.LoadOptions(server, imdsaws.Options{EndpointMode: ec2imds.EndpointModeStateIPv6}) {
+		require.NoError(t, opt(&o))
+	}
+	assert.Equal(t, ec2imds.EndpointModeStateIPv6, o.EC2IMDSEndpointMode)
+}
+
+func TestCredentialsProviderRefresh(t *testing.T) {
+	server := startServer(t, imds.Options{
+		RandomPort:          true,
+		IAMRole:             "web-app",
+		CredentialsLifetime: time.Second,
+		IMDSv2:              true,
+	})
+
+	provider := aws.NewCredentialsCache(ec2rolecreds.New(func(o *ec2rolecreds.Options) {
+		o.Client = imdsaws.NewClient(server, imdsaws.Options{DisableIMDSv1Fallback: true})
+	}))
+
+	first, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.True(t, first.CanExpire)
+
+	time.Sleep(time.Until(first.Expires))
+
+	refreshed, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, first.AccessKeyID, refreshed.AccessKeyID)
+}
+
+func TestSetenv(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+	imdstest.Setenv(t, server)
+
+	// The AWS SDK should resolve the mock from the environment alone
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	require.NoError(t, err)
+
+	client := ec2imds.NewFromConfig(cfg)
+	assert.Equal(t, imds.ValueInstanceID, getMetadata(t, client, imds.PathInstanceID))
+}
+
+func getMetadata(t *testing.T, client *ec2imds.Client, category string) string {
+	t.Helper()
+
+	out, err := client.GetMetadata(context.Background(), &ec2imds.GetMetadataInput{Path: category})
+	require.NoError(t, err)
+	defer out.Content.Close()
+
+	data, err := io.ReadAll(out.Content)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func startServer(t *testing.T, opts imds.Options) *imds.Server {
+	t.Helper()
+
+	server, err := imds.StartServer(context.Background(), opts)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return server
+}
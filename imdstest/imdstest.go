@@ -0,0 +1,44 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package imdstest provides helpers for using the Instance Metadata Mock (imds-mock)
+// within tests
+package imdstest
+
+import (
+	"strings"
+	"testing"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+)
+
+// Setenv sets the environment variables returned by imds.Env() for the duration of a
+// test, restoring their original values during cleanup. As it changes the environment
+// of the current process, it cannot be used in parallel tests
+func Setenv(t testing.TB, mock imds.IMDS) {
+	t.Helper()
+
+	for _, env := range imds.Env(mock) {
+		key, value, _ := strings.Cut(env, "=")
+		t.Setenv(key, value)
+	}
+}
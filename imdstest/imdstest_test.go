@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imdstest_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/purpleclay/testcontainers-imds/imdstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetenv(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+	imdstest.Setenv(t, server)
+
+	for _, env := range imds.Env(server) {
+		key, value, _ := strings.Cut(env, "=")
+		assert.Equal(t, value, os.Getenv(key))
+	}
+}
+
+func startServer(t *testing.T, opts imds.Options) *imds.Server {
+	t.Helper()
+
+	server, err := imds.StartServer(context.Background(), opts)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return server
+}
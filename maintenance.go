@@ -0,0 +1,164 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Codes that identify the type of a scheduled maintenance event
+const (
+	MaintenanceEventCodeInstanceReboot     = "instance-reboot"
+	MaintenanceEventCodeSystemReboot       = "system-reboot"
+	MaintenanceEventCodeSystemMaintenance  = "system-maintenance"
+	MaintenanceEventCodeInstanceRetirement = "instance-retirement"
+	MaintenanceEventCodeInstanceStop       = "instance-stop"
+)
+
+// States of a scheduled maintenance event
+const (
+	MaintenanceEventStateActive    = "active"
+	MaintenanceEventStateCompleted = "completed"
+	MaintenanceEventStateCanceled  = "canceled"
+)
+
+// maintenanceEvents tracks all maintenance events injected at runtime, both those that
+// are scheduled and those moved into history
+type maintenanceEvents struct {
+	scheduled []MaintenanceEvent
+	history   []MaintenanceEvent
+	mu        sync.Mutex
+}
+
+// ScheduleMaintenanceEvent injects a maintenance event at runtime, exposing it through
+// the events/maintenance/scheduled category. An EventID is generated if not provided,
+// and State defaults to active. The scheduled event is returned. Any maintenance events
+// served by the mock are replaced. ErrNotMutable is returned if the container was not
+// started with Mutable
+func (c *client) ScheduleMaintenanceEvent(event MaintenanceEvent) (MaintenanceEvent, error) {
+	if !c.mutable {
+		return MaintenanceEvent{}, ErrNotMutable
+	}
+
+	if event.EventID == "" {
+		id, err := maintenanceEventID()
+		if err != nil {
+			return MaintenanceEvent{}, err
+		}
+		event.EventID = id
+	}
+
+	if event.State == "" {
+		event.State = MaintenanceEventStateActive
+	}
+
+	c.maintenance.mu.Lock()
+	defer c.maintenance.mu.Unlock()
+
+	c.maintenance.scheduled = append(c.maintenance.scheduled, event)
+	return event, c.serveMaintenanceEvents()
+}
+
+// CompleteMaintenanceEvent moves a scheduled maintenance event into the
+// events/maintenance/history category at runtime, marking it as completed.
+// ErrMaintenanceEventNotFound is returned if no event is scheduled with the given ID.
+// ErrNotMutable is returned if the container was not started with Mutable
+func (c *client) CompleteMaintenanceEvent(eventID string) error {
+	return c.endMaintenanceEvent(eventID, MaintenanceEventStateCompleted, "[Completed] ")
+}
+
+// CancelMaintenanceEvent moves a scheduled maintenance event into the
+// events/maintenance/history category at runtime, marking it as canceled.
+// ErrMaintenanceEventNotFound is returned if no event is scheduled with the given ID.
+// ErrNotMutable is returned if the container was not started with Mutable
+func (c *client) CancelMaintenanceEvent(eventID string) error {
+	return c.endMaintenanceEvent(eventID, MaintenanceEventStateCanceled, "[Canceled] ")
+}
+
+func (c *client) endMaintenanceEvent(eventID, state, prefix string) error {
+	if !c.mutable {
+		return ErrNotMutable
+	}
+
+	c.maintenance.mu.Lock()
+	defer c.maintenance.mu.Unlock()
+
+	for i, event := range c.maintenance.scheduled {
+		if event.EventID != eventID {
+			continue
+		}
+
+		c.maintenance.scheduled = append(c.maintenance.scheduled[:i], c.maintenance.scheduled[i+1:]...)
+
+		// IMDS prefixes the description of an event within history with its outcome
+		event.State = state
+		event.Description = prefix + event.Description
+		c.maintenance.history = append(c.maintenance.history, event)
+		return c.serveMaintenanceEvents()
+	}
+
+	return fmt.Errorf("%w: %s", ErrMaintenanceEventNotFound, eventID)
+}
+
+// serveMaintenanceEvents exposes all scheduled and historic maintenance events through
+// the overlay. An empty JSON array is served when no events exist, matching IMDS
+func (c *client) serveMaintenanceEvents() error {
+	scheduled, err := marshalMaintenanceEvents(c.maintenance.scheduled)
+	if err != nil {
+		return err
+	}
+
+	history, err := marshalMaintenanceEvents(c.maintenance.history)
+	if err != nil {
+		return err
+	}
+
+	c.overlay.set(PathEventsMaintenanceScheduled, scheduled)
+	c.overlay.set(PathEventsMaintenanceHistory, history)
+	return nil
+}
+
+func marshalMaintenanceEvents(events []MaintenanceEvent) (string, error) {
+	if events == nil {
+		events = []MaintenanceEvent{}
+	}
+
+	out, err := json.Marshal(events)
+	return string(out), err
+}
+
+// maintenanceEventID generates a unique ID in the same format as IMDS, for example:
+//
+//	instance-event-0d59937288b749b32
+func maintenanceEventID() (string, error) {
+	id := make([]byte, 9)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return "instance-event-" + hex.EncodeToString(id)[:17], nil
+}
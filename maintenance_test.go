@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleMaintenanceEvent(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+
+	event, err := server.ScheduleMaintenanceEvent(imds.MaintenanceEvent{
+		Code:        imds.MaintenanceEventCodeSystemReboot,
+		Description: "scheduled reboot",
+		NotBefore:   time.Date(2023, time.March, 14, 9, 0, 0, 0, time.UTC),
+		NotAfter:    time.Date(2023, time.March, 14, 11, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, "^instance-event-[0-9a-f]{17}$", event.EventID)
+	assert.Equal(t, imds.MaintenanceEventStateActive, event.State)
+
+	events, err := server.ScheduledMaintenanceEvents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []imds.MaintenanceEvent{event}, events)
+
+	history, err := server.MaintenanceEventHistory(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestCompleteMaintenanceEvent(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+
+	event, err := server.ScheduleMaintenanceEvent(imds.MaintenanceEvent{
+		Code:        imds.MaintenanceEventCodeInstanceReboot,
+		Description: "scheduled reboot",
+		EventID:     "instance-event-0d59937288b749b32",
+		NotBefore:   time.Date(2023, time.March, 14, 9, 0, 0, 0, time.UTC),
+		NotAfter:    time.Date(2023, time.March, 14, 11, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, server.CompleteMaintenanceEvent(event.EventID))
+
+	events, err := server.ScheduledMaintenanceEvents(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	history, err := server.MaintenanceEventHistory(ctx)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "instance-event-0d59937288b749b32", history[0].EventID)
+	assert.Equal(t, imds.MaintenanceEventStateCompleted, history[0].State)
+	assert.Equal(t, "[Completed] scheduled reboot", history[0].Description)
+}
+
+func TestCancelMaintenanceEvent(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+
+	retirement, err := server.ScheduleMaintenanceEvent(imds.MaintenanceEvent{
+		Code:        imds.MaintenanceEventCodeInstanceRetirement,
+		Description: "scheduled retirement",
+		NotBefore:   time.Date(2023, time.March, 14, 9, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	stop, err := server.ScheduleMaintenanceEvent(imds.MaintenanceEvent{
+		Code:        imds.MaintenanceEventCodeInstanceStop,
+		Description: "scheduled stop",
+		NotBefore:   time.Date(2023, time.March, 15, 9, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, server.CancelMaintenanceEvent(retirement.EventID))
+
+	events, err := server.ScheduledMaintenanceEvents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []imds.MaintenanceEvent{stop}, events)
+
+	history, err := server.MaintenanceEventHistory(ctx)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, imds.MaintenanceEventStateCanceled, history[0].State)
+	assert.Equal(t, "[Canceled] scheduled retirement", history[0].Description)
+}
+
+func TestCancelMaintenanceEvent_NotFound(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+
+	err := server.CancelMaintenanceEvent("instance-event-0d59937288b749b32")
+	assert.ErrorIs(t, err, imds.ErrMaintenanceEventNotFound)
+}
+
+func TestScheduleMaintenanceEvent_NotMutable(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	_, err := server.ScheduleMaintenanceEvent(imds.MaintenanceEvent{
+		Code: imds.MaintenanceEventCodeSystemReboot,
+	})
+	assert.ErrorIs(t, err, imds.ErrNotMutable)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// InstanceID retrieves the ID of the instance
+//
+//	i-0decb1524582da041
+func (c *client) InstanceID(ctx context.Context) (string, error) {
+	return c.value(ctx, PathInstanceID)
+}
+
+// InstanceType retrieves the type of the instance
+//
+//	m4.xlarge
+func (c *client) InstanceType(ctx context.Context) (string, error) {
+	return c.value(ctx, PathInstanceType)
+}
+
+// AMIID retrieves the ID of the AMI used to launch the instance
+//
+//	ami-0e34bbddc66def5ac
+func (c *client) AMIID(ctx context.Context) (string, error) {
+	return c.value(ctx, PathAMIID)
+}
+
+// Region retrieves the AWS region in which the instance was launched
+//
+//	us-east-1
+func (c *client) Region(ctx context.Context) (string, error) {
+	return c.value(ctx, PathPlacementRegion)
+}
+
+// AvailabilityZone retrieves the availability zone in which the instance was launched
+//
+//	us-east-1a
+func (c *client) AvailabilityZone(ctx context.Context) (string, error) {
+	return c.value(ctx, PathPlacementAvailabilityZone)
+}
+
+// AvailabilityZoneID retrieves the static ID of the availability zone in which the
+// instance was launched
+//
+//	use1-az4
+func (c *client) AvailabilityZoneID(ctx context.Context) (string, error) {
+	return c.value(ctx, PathPlacementAvailabilityZoneID)
+}
+
+// Hostname retrieves the private IPv4 DNS hostname of the instance
+//
+//	ip-10-0-1-100.us-east-1.compute.internal
+func (c *client) Hostname(ctx context.Context) (string, error) {
+	return c.value(ctx, PathHostname)
+}
+
+// LocalIPv4 retrieves the private IPv4 address of the instance
+//
+//	10.0.1.100
+func (c *client) LocalIPv4(ctx context.Context) (net.IP, error) {
+	value, err := c.value(ctx, PathLocalIPv4)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("instance category %q is not a valid IP address: %s", PathLocalIPv4, value)
+	}
+
+	return ip, nil
+}
+
+// MAC retrieves the MAC address of the primary network interface of the instance
+//
+//	06:e5:43:29:8f:08
+func (c *client) MAC(ctx context.Context) (net.HardwareAddr, error) {
+	value, err := c.value(ctx, PathMAC)
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := net.ParseMAC(value)
+	if err != nil {
+		return nil, fmt.Errorf("instance category %q is not a valid MAC address: %w", PathMAC, err)
+	}
+
+	return mac, nil
+}
+
+// SubnetIPv4CIDRBlock retrieves the IPv4 CIDR block of the subnet in which the
+// primary network interface of the instance resides
+//
+//	10.0.1.0/24
+func (c *client) SubnetIPv4CIDRBlock(ctx context.Context) (netip.Prefix, error) {
+	return c.primaryInterfacePrefix(ctx, InterfaceFieldSubnetIPv4CIDRBlock)
+}
+
+// VPCIPv4CIDRBlock retrieves the primary IPv4 CIDR block of the VPC in which the
+// primary network interface of the instance resides
+//
+//	10.0.0.0/16
+func (c *client) VPCIPv4CIDRBlock(ctx context.Context) (netip.Prefix, error) {
+	return c.primaryInterfacePrefix(ctx, InterfaceFieldVPCIPv4CIDRBlock)
+}
+
+// InstanceTag retrieves the value of an instance tag. ErrCategoryNotFound is returned
+// if the tag does not exist, or instance tags have been excluded
+func (c *client) InstanceTag(ctx context.Context, tag string) (string, error) {
+	return c.value(ctx, InstanceTagPath(tag))
+}
+
+// UserData retrieves the user data supplied when the instance was launched. The raw
+// data is returned without being decoded or decompressed. ErrCategoryNotFound is
+// returned if no user data exists
+func (c *client) UserData(ctx context.Context) ([]byte, error) {
+	out, _, err := c.getManaged(ctx, c.endpoint+"/latest/", "user-data")
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(out), nil
+}
+
+func (c *client) primaryInterfacePrefix(ctx context.Context, field string) (netip.Prefix, error) {
+	mac, err := c.value(ctx, PathMAC)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	category := NetworkInterfacePath(mac, field)
+
+	value, err := c.value(ctx, category)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("instance category %q is not a valid CIDR block: %w", category, err)
+	}
+
+	return prefix, nil
+}
+
+// value retrieves the raw value of an instance category
+func (c *client) value(ctx context.Context, category string) (string, error) {
+	out, _, err := c.GetContext(ctx, category)
+	if err != nil {
+		return "", err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedAccessors(t *testing.T) {
+	container := startWithDefaults(t)
+	ctx := context.Background()
+
+	t.Run("InstanceID", checkAccessor(ctx, container.InstanceID, imds.ValueInstanceID))
+	t.Run("InstanceType", checkAccessor(ctx, container.InstanceType, imds.ValueInstanceType))
+	t.Run("AMIID", checkAccessor(ctx, container.AMIID, imds.ValueAMIID))
+	t.Run("Region", checkAccessor(ctx, container.Region, imds.ValuePlacementRegion))
+	t.Run("AvailabilityZone", checkAccessor(ctx, container.AvailabilityZone, imds.ValuePlacementAvailabilityZone))
+	t.Run("AvailabilityZoneID", checkAccessor(ctx, container.AvailabilityZoneID, imds.ValuePlacementAvailabilityZoneID))
+	t.Run("Hostname", checkAccessor(ctx, container.Hostname, imds.ValueHostname))
+	t.Run("LocalIPv4", checkAccessor(ctx, container.LocalIPv4, net.ParseIP(imds.ValueLocalIPv4)))
+	t.Run("MAC", checkAccessor(ctx, container.MAC, mustParseMAC(t, imds.ValueMAC)))
+	t.Run("SubnetIPv4CIDRBlock", checkAccessor(ctx, container.SubnetIPv4CIDRBlock,
+		netip.MustParsePrefix(imds.ValueNetworkInterfaces0SubnetIPv4CIDRBlock)))
+	t.Run("VPCIPv4CIDRBlock", checkAccessor(ctx, container.VPCIPv4CIDRBlock,
+		netip.MustParsePrefix(imds.ValueNetworkInterfaces0VPCIDPv4CIDRBlock)))
+}
+
+// Utility wrapper, tidying up inline test functions
+func checkAccessor[T any](ctx context.Context, accessor func(context.Context) (T, error), value T) func(t *testing.T) {
+	return func(t *testing.T) {
+		v, err := accessor(ctx)
+		require.NoError(t, err)
+		require.Equal(t, value, v)
+	}
+}
+
+func mustParseMAC(t *testing.T, s string) net.HardwareAddr {
+	t.Helper()
+
+	mac, err := net.ParseMAC(s)
+	require.NoError(t, err)
+
+	return mac
+}
+
+func TestInstanceTag(t *testing.T) {
+	container := startWithOptions(t, imds.Options{InstanceTags: map[string]string{"Name": "testing"}})
+
+	tag, err := container.InstanceTag(context.Background(), "Name")
+
+	require.NoError(t, err)
+	assert.Equal(t, "testing", tag)
+}
+
+func TestInstanceTag_NotFound(t *testing.T) {
+	container := startWithDefaults(t)
+
+	_, err := container.InstanceTag(context.Background(), "Unknown")
+
+	require.ErrorIs(t, err, imds.ErrCategoryNotFound)
+
+	var statusErr *imds.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	assert.Equal(t, "tags/instance/Unknown", statusErr.Category)
+}
+
+func TestUserData(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte("#cloud-config\npackages:\n  - jq\n"))
+	require.NoError(t, gz.Close())
+
+	container := startWithOptions(t, imds.Options{UserData: buf.Bytes()})
+
+	userData, err := container.UserData(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, buf.Bytes(), userData)
+}
+
+func TestUserData_NotFound(t *testing.T) {
+	container := startWithDefaults(t)
+
+	_, err := container.UserData(context.Background())
+
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+// SetValue sets the value of an instance category at runtime, adding it if it does not
+// already exist. Any subsequent request for the category will return the new value.
+// ErrNotMutable is returned if the container was not started with Mutable
+func (c *client) SetValue(category, value string) error {
+	if !c.mutable {
+		return ErrNotMutable
+	}
+
+	c.overlay.set(category, value)
+	return nil
+}
+
+// SetTags replaces all instance tags exposed through the tags/instance category at
+// runtime. Providing no tags will remove the category entirely. ErrNotMutable is
+// returned if the container was not started with Mutable
+func (c *client) SetTags(tags map[string]string) error {
+	if !c.mutable {
+		return ErrNotMutable
+	}
+
+	values := make(map[string]string, len(tags))
+	for tag, value := range tags {
+		values[InstanceTagPath(tag)] = value
+	}
+
+	c.overlay.replaceTree(PathTagsInstance, values)
+	return nil
+}
+
+// Delete removes an instance category, along with all of its descendants, at runtime.
+// Any subsequent request for the category will return ErrCategoryNotFound. ErrNotMutable
+// is returned if the container was not started with Mutable
+func (c *client) Delete(category string) error {
+	if !c.mutable {
+		return ErrNotMutable
+	}
+
+	c.overlay.remove(category)
+	return nil
+}
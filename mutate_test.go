@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"testing"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetValue(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+
+	require.NoError(t, server.SetValue(imds.PathHostname, "ip-10-0-1-200.us-east-1.compute.internal"))
+
+	out, _, err := server.Get(imds.PathHostname)
+	require.NoError(t, err)
+	assert.Equal(t, "ip-10-0-1-200.us-east-1.compute.internal", out)
+}
+
+func TestSetValue_NotMutable(t *testing.T) {
+	container := startWithDefaults(t)
+
+	err := container.SetValue(imds.PathHostname, "ip-10-0-1-200.us-east-1.compute.internal")
+	assert.ErrorIs(t, err, imds.ErrNotMutable)
+}
+
+func TestSetValue_Mutable(t *testing.T) {
+	container := startWithOptions(t, imds.Options{RandomPort: true, Mutable: true})
+
+	require.NoError(t, container.SetValue(imds.PathInstanceType, "m7g.large"))
+
+	out, _, err := container.Get(imds.PathInstanceType)
+	require.NoError(t, err)
+	assert.Equal(t, "m7g.large", out)
+}
+
+func TestSetTags(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+
+	require.NoError(t, server.SetTags(map[string]string{
+		"Environment": "dev",
+		"Team":        "platform",
+	}))
+
+	out, _, err := server.Get(imds.PathTagsInstance)
+	require.NoError(t, err)
+	assert.Equal(t, "Environment\nTeam", out)
+
+	out, _, err = server.Get(imds.InstanceTagPath("Team"))
+	require.NoError(t, err)
+	assert.Equal(t, "platform", out)
+
+	_, _, err = server.Get(imds.InstanceTagPath("Name"))
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+}
+
+func TestDelete(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+
+	require.NoError(t, server.Delete("placement"))
+
+	_, _, err := server.Get(imds.PathPlacementRegion)
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+
+	out, _, err := server.Get(imds.AllCategories)
+	require.NoError(t, err)
+	assert.NotContains(t, out, "placement")
+}
+
+func TestDelete_ThenSetValue(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+
+	require.NoError(t, server.Delete("placement"))
+	require.NoError(t, server.SetValue(imds.PathPlacementRegion, "eu-west-2"))
+
+	out, _, err := server.Get("placement")
+	require.NoError(t, err)
+	assert.Equal(t, "region", out)
+
+	out, _, err = server.Get(imds.PathPlacementRegion)
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-2", out)
+}
+
+func TestStartServer_NotMutable(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	err := server.SetValue(imds.PathHostname, "ip-10-0-1-200.us-east-1.compute.internal")
+	assert.ErrorIs(t, err, imds.ErrNotMutable)
+}
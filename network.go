@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	// LinkLocalAddress is the IPv4 link-local address of the Amazon EC2 Metadata Service
+	LinkLocalAddress = "169.254.169.254"
+
+	// LinkLocalSubnet is a subnet that includes the link-local address, suitable for
+	// any Docker network that the container will join with LinkLocal enabled
+	LinkLocalSubnet = "169.254.169.0/24"
+
+	// IPv6Address is the IPv6 address of the Amazon EC2 Metadata Service, available
+	// on Nitro instances
+	IPv6Address = "fd00:ec2::254"
+
+	// IPv6Subnet is a subnet that includes the IPv6 address, suitable for any Docker
+	// network that the container will join with IPv6 enabled
+	IPv6Subnet = "fd00:ec2::/64"
+)
+
+// NewLinkLocalNetwork will create a Docker network with a subnet that includes the
+// link-local address of the Amazon EC2 Metadata Service. Any container that joins the
+// network with LinkLocal enabled will be reachable through the link-local address. As
+// the caller it is your responsibility to remove the network by invoking the Remove()
+// method on the network
+func NewLinkLocalNetwork(ctx context.Context, name string) (testcontainers.Network, error) {
+	return testcontainers.GenericNetwork(ctx, testcontainers.GenericNetworkRequest{
+		NetworkRequest: testcontainers.NetworkRequest{
+			Name:           name,
+			CheckDuplicate: true,
+			Attachable:     true,
+			IPAM: &network.IPAM{
+				Config: []network.IPAMConfig{{Subnet: LinkLocalSubnet}},
+			},
+		},
+	})
+}
+
+// NewIPv6Network will create a dual-stack Docker network with subnets that include both
+// the link-local and IPv6 addresses of the Amazon EC2 Metadata Service. Any container
+// that joins the network with either LinkLocal or IPv6 enabled will be reachable
+// through the respective address. The Docker daemon must support IPv6 networking. As
+// the caller it is your responsibility to remove the network by invoking the Remove()
+// method on the network
+func NewIPv6Network(ctx context.Context, name string) (testcontainers.Network, error) {
+	return testcontainers.GenericNetwork(ctx, testcontainers.GenericNetworkRequest{
+		NetworkRequest: testcontainers.NetworkRequest{
+			Name:           name,
+			CheckDuplicate: true,
+			Attachable:     true,
+			EnableIPv6:     true,
+			IPAM: &network.IPAM{
+				Config: []network.IPAMConfig{
+					{Subnet: LinkLocalSubnet},
+					{Subnet: IPv6Subnet},
+				},
+			},
+		},
+	})
+}
+
+// LinkLocalURL returns the URL for accessing the metadata endpoint of the container
+// from any sibling container within the same network. Only available if the container
+// was started with LinkLocal, otherwise an empty string is returned
+//
+//	http://169.254.169.254/latest/meta-data/
+func (c *Container) LinkLocalURL() string {
+	if !c.linkLocal {
+		return ""
+	}
+
+	return "http://" + LinkLocalAddress + "/latest/meta-data/"
+}
+
+// IPv6URL returns the URL for accessing the metadata endpoint of the container through
+// its IPv6 address, from any sibling container within the same network. Only available
+// if the container was started with IPv6, otherwise an empty string is returned
+//
+//	http://[fd00:ec2::254]/latest/meta-data/
+func (c *Container) IPv6URL() string {
+	if !c.ipv6 {
+		return ""
+	}
+
+	return "http://[" + IPv6Address + "]/latest/meta-data/"
+}
@@ -0,0 +1,231 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestStartWith_LinkLocal(t *testing.T) {
+	ctx := context.Background()
+
+	network, err := imds.NewLinkLocalNetwork(ctx, "imds-link-local")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		network.Remove(ctx)
+	})
+
+	container := startWithOptions(t, imds.Options{
+		RandomPort:     true,
+		Network:        "imds-link-local",
+		NetworkAliases: []string{"imds"},
+		LinkLocal:      true,
+	})
+
+	ips, err := container.ContainerIPs(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, ips, imds.LinkLocalAddress)
+
+	aliases, err := container.NetworkAliases(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, aliases["imds-link-local"], "imds")
+
+	assert.Equal(t, "http://169.254.169.254/latest/meta-data/", container.LinkLocalURL())
+
+	out, _ := get(t, container.URL()+imds.PathInstanceID)
+	assert.Equal(t, imds.ValueInstanceID, out)
+}
+
+func TestStartWith_LinkLocalSibling(t *testing.T) {
+	ctx := context.Background()
+
+	network, err := imds.NewLinkLocalNetwork(ctx, "imds-link-local-sibling")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		network.Remove(ctx)
+	})
+
+	container := startWithOptions(t, imds.Options{
+		RandomPort: true,
+		Network:    "imds-link-local-sibling",
+		LinkLocal:  true,
+	})
+
+	out := curlFromSibling(t, "imds-link-local-sibling", nil, container.LinkLocalURL()+imds.PathInstanceID)
+	assert.Equal(t, imds.ValueInstanceID, out)
+}
+
+func TestStartWith_LinkLocalIMDSv2(t *testing.T) {
+	_, err := imds.StartWith(context.Background(), imds.Options{
+		Network:   "imds-link-local",
+		LinkLocal: true,
+		IMDSv2:    true,
+	})
+	require.Error(t, err)
+}
+
+func TestStartWith_LinkLocalRequiresNetwork(t *testing.T) {
+	_, err := imds.StartWith(context.Background(), imds.Options{LinkLocal: true})
+	require.Error(t, err)
+}
+
+func TestStartWith_ProxyOnlyWithinNetwork(t *testing.T) {
+	tests := []struct {
+		name string
+		opts imds.Options
+	}{
+		{name: "Overrides", opts: imds.Options{Overrides: map[string]string{imds.PathInstanceType: "m7g.large"}}},
+		{name: "Document", opts: imds.Options{Document: strings.NewReader("instance-id: i-0123456789abcdef0")}},
+		{name: "DocumentFile", opts: imds.Options{DocumentFile: "instance.yaml"}},
+		{name: "UserData", opts: imds.Options{UserData: []byte("#!/bin/bash")}},
+		{name: "InstanceIdentity", opts: imds.Options{InstanceIdentity: true}},
+		{name: "IAMRole", opts: imds.Options{IAMRole: "web-app"}},
+		{name: "CredentialsLifetime", opts: imds.Options{CredentialsLifetime: time.Hour}},
+		{name: "NetworkInterfaces", opts: imds.Options{NetworkInterfaces: []imds.NetworkInterface{{MAC: "0a:1b:2c:3d:4e:5f"}}}},
+		{name: "Rebalance", opts: imds.Options{Rebalance: true}},
+		{name: "Autoscaling", opts: imds.Options{Autoscaling: true}},
+		{name: "Mutable", opts: imds.Options{Mutable: true}},
+		{name: "Scenario", opts: imds.Options{Scenario: &imds.Scenario{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Network = "imds-link-local"
+			_, err := imds.StartWith(context.Background(), tt.opts)
+			assert.ErrorContains(t, err, "cannot be combined with a network")
+		})
+	}
+}
+
+func TestStartWith_IPv6(t *testing.T) {
+	ctx := context.Background()
+
+	network, err := imds.NewIPv6Network(ctx, "imds-ipv6")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		network.Remove(ctx)
+	})
+
+	container := startWithOptions(t, imds.Options{
+		RandomPort: true,
+		Network:    "imds-ipv6",
+		LinkLocal:  true,
+		IPv6:       true,
+	})
+
+	docker, err := testcontainers.NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	defer docker.Close()
+
+	inspect, err := docker.ContainerInspect(ctx, container.GetContainerID())
+	require.NoError(t, err)
+
+	var ipv6s []string
+	for _, endpoint := range inspect.NetworkSettings.Networks {
+		ipv6s = append(ipv6s, endpoint.GlobalIPv6Address)
+	}
+	assert.Contains(t, ipv6s, imds.IPv6Address)
+
+	assert.Equal(t, "http://[fd00:ec2::254]/latest/meta-data/", container.IPv6URL())
+	assert.Equal(t, "http://169.254.169.254/latest/meta-data/", container.LinkLocalURL())
+
+	out, _ := get(t, container.URL()+imds.PathInstanceID)
+	assert.Equal(t, imds.ValueInstanceID, out)
+}
+
+func TestStartWith_IPv6Sibling(t *testing.T) {
+	ctx := context.Background()
+
+	network, err := imds.NewIPv6Network(ctx, "imds-ipv6-sibling")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		network.Remove(ctx)
+	})
+
+	container := startWithOptions(t, imds.Options{
+		RandomPort: true,
+		Network:    "imds-ipv6-sibling",
+		IPv6:       true,
+	})
+
+	// Mirror how an IPv6-only workload would be configured to select the IPv6 endpoint
+	env := map[string]string{imds.EnvEndpointMode: "IPv6"}
+
+	out := curlFromSibling(t, "imds-ipv6-sibling", env, container.IPv6URL()+imds.PathInstanceID)
+	assert.Equal(t, imds.ValueInstanceID, out)
+}
+
+func TestStartWith_IPv6IMDSv2(t *testing.T) {
+	_, err := imds.StartWith(context.Background(), imds.Options{
+		Network: "imds-ipv6",
+		IPv6:    true,
+		IMDSv2:  true,
+	})
+	require.Error(t, err)
+}
+
+func TestStartWith_IPv6RequiresNetwork(t *testing.T) {
+	_, err := imds.StartWith(context.Background(), imds.Options{IPv6: true})
+	require.Error(t, err)
+}
+
+// curlFromSibling fetches the URL from a sibling container within the network, returning
+// the response body
+func curlFromSibling(t *testing.T, network string, env map[string]string, url string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	sibling, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      "curlimages/curl:8.4.0",
+			Cmd:        []string{"-sfg", url},
+			Env:        env,
+			Networks:   []string{network},
+			WaitingFor: wait.ForExit(),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sibling.Terminate(ctx)
+	})
+
+	logs, err := sibling.Logs(ctx)
+	require.NoError(t, err)
+	defer logs.Close()
+
+	out, err := io.ReadAll(logs)
+	require.NoError(t, err)
+
+	return string(out)
+}
@@ -0,0 +1,232 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Fields of a network interface, each served as a category beneath the MAC address of
+// the interface. A path to a field can be generated using NetworkInterfacePath
+const (
+	InterfaceFieldDeviceNumber         = "device-number"
+	InterfaceFieldInterfaceID          = "interface-id"
+	InterfaceFieldIPv4Associations     = "ipv4-associations"
+	InterfaceFieldIPv6s                = "ipv6s"
+	InterfaceFieldLocalHostname        = "local-hostname"
+	InterfaceFieldLocalIPv4s           = "local-ipv4s"
+	InterfaceFieldMAC                  = "mac"
+	InterfaceFieldOwnerID              = "owner-id"
+	InterfaceFieldPublicIPv4s          = "public-ipv4s"
+	InterfaceFieldSecurityGroupIDs     = "security-group-ids"
+	InterfaceFieldSecurityGroups       = "security-groups"
+	InterfaceFieldSubnetID             = "subnet-id"
+	InterfaceFieldSubnetIPv4CIDRBlock  = "subnet-ipv4-cidr-block"
+	InterfaceFieldSubnetIPv6CIDRBlocks = "subnet-ipv6-cidr-blocks"
+	InterfaceFieldVPCID                = "vpc-id"
+	InterfaceFieldVPCIPv4CIDRBlock     = "vpc-ipv4-cidr-block"
+	InterfaceFieldVPCIPv4CIDRBlocks    = "vpc-ipv4-cidr-blocks"
+	InterfaceFieldVPCIPv6CIDRBlocks    = "vpc-ipv6-cidr-blocks"
+)
+
+const (
+	// pathNetworkInterfaces is the parent category of all network interfaces
+	pathNetworkInterfaces = "network/interfaces/macs"
+
+	// pathPublicIPv4 is not supported by the mock, so is only served for a network
+	// interface with a public IPv4 address
+	pathPublicIPv4 = "public-ipv4"
+)
+
+// NetworkInterfacePath generates a network interface category path based on the MAC
+// address of the interface and the provided field
+//
+//	network/interfaces/macs/06:e5:43:29:8f:08/local-ipv4s
+func NetworkInterfacePath(mac, field string) string {
+	return pathNetworkInterfaces + "/" + mac + "/" + field
+}
+
+// NetworkInterface defines an elastic network interface (ENI) attached to the instance.
+// Only the MAC address is required, any other empty field will not be served
+type NetworkInterface struct {
+	// MAC is the unique MAC address of the interface
+	MAC string
+
+	// DeviceNumber is the position of the interface on the instance. The interface
+	// with a device number of 0 is the primary interface
+	DeviceNumber int
+
+	// InterfaceID is the ID of the interface
+	InterfaceID string
+
+	// OwnerID is the ID of the AWS account that owns the interface
+	OwnerID string
+
+	// LocalHostname is the private hostname of the interface
+	LocalHostname string
+
+	// PrivateIPv4s lists the private IPv4 addresses of the interface, where the first
+	// address is the primary private IPv4 address
+	PrivateIPv4s []string
+
+	// PublicIPv4s lists the public IPv4 addresses of the interface. Each address is
+	// associated with the private IPv4 address at the same position, or the primary
+	// private IPv4 address if none exists
+	PublicIPv4s []string
+
+	// IPv6s lists the IPv6 addresses of the interface
+	IPv6s []string
+
+	// SecurityGroups lists the names of the security groups applied to the interface
+	SecurityGroups []string
+
+	// SecurityGroupIDs lists the IDs of the security groups applied to the interface
+	SecurityGroupIDs []string
+
+	// SubnetID is the ID of the subnet in which the interface resides
+	SubnetID string
+
+	// SubnetIPv4CIDRBlock is the IPv4 CIDR block of the subnet
+	SubnetIPv4CIDRBlock string
+
+	// SubnetIPv6CIDRBlocks lists the IPv6 CIDR blocks of the subnet
+	SubnetIPv6CIDRBlocks []string
+
+	// VPCID is the ID of the VPC in which the interface resides
+	VPCID string
+
+	// VPCIPv4CIDRBlocks lists the IPv4 CIDR blocks of the VPC, where the first block
+	// is the primary IPv4 CIDR block
+	VPCIPv4CIDRBlocks []string
+
+	// VPCIPv6CIDRBlocks lists the IPv6 CIDR blocks of the VPC
+	VPCIPv6CIDRBlocks []string
+}
+
+// categories flattens the interface into a set of instance categories, keyed by the
+// path of each field
+func (n NetworkInterface) categories() map[string]string {
+	fields := map[string]string{
+		InterfaceFieldDeviceNumber:         strconv.Itoa(n.DeviceNumber),
+		InterfaceFieldInterfaceID:          n.InterfaceID,
+		InterfaceFieldIPv6s:                strings.Join(n.IPv6s, "\n"),
+		InterfaceFieldLocalHostname:        n.LocalHostname,
+		InterfaceFieldLocalIPv4s:           strings.Join(n.PrivateIPv4s, "\n"),
+		InterfaceFieldMAC:                  n.MAC,
+		InterfaceFieldOwnerID:              n.OwnerID,
+		InterfaceFieldPublicIPv4s:          strings.Join(n.PublicIPv4s, "\n"),
+		InterfaceFieldSecurityGroupIDs:     strings.Join(n.SecurityGroupIDs, "\n"),
+		InterfaceFieldSecurityGroups:       strings.Join(n.SecurityGroups, "\n"),
+		InterfaceFieldSubnetID:             n.SubnetID,
+		InterfaceFieldSubnetIPv4CIDRBlock:  n.SubnetIPv4CIDRBlock,
+		InterfaceFieldSubnetIPv6CIDRBlocks: strings.Join(n.SubnetIPv6CIDRBlocks, "\n"),
+		InterfaceFieldVPCID:                n.VPCID,
+		InterfaceFieldVPCIPv4CIDRBlocks:    strings.Join(n.VPCIPv4CIDRBlocks, "\n"),
+		InterfaceFieldVPCIPv6CIDRBlocks:    strings.Join(n.VPCIPv6CIDRBlocks, "\n"),
+	}
+
+	if len(n.VPCIPv4CIDRBlocks) > 0 {
+		fields[InterfaceFieldVPCIPv4CIDRBlock] = n.VPCIPv4CIDRBlocks[0]
+	}
+
+	for i, public := range n.PublicIPv4s {
+		switch {
+		case i < len(n.PrivateIPv4s):
+			fields[InterfaceFieldIPv4Associations+"/"+public] = n.PrivateIPv4s[i]
+		case len(n.PrivateIPv4s) > 0:
+			fields[InterfaceFieldIPv4Associations+"/"+public] = n.PrivateIPv4s[0]
+		}
+	}
+
+	categories := map[string]string{}
+	for field, value := range fields {
+		if value != "" {
+			categories[NetworkInterfacePath(n.MAC, field)] = value
+		}
+	}
+
+	return categories
+}
+
+// serveNetworkInterfaces serves the network interfaces through the overlay, replacing
+// those of the mock entirely. The primary interface also defines the top-level mac,
+// local-ipv4 and public-ipv4 categories
+func serveNetworkInterfaces(o *overlay, interfaces []NetworkInterface) error {
+	if err := validateNetworkInterfaces(interfaces); err != nil {
+		return err
+	}
+
+	o.hide(pathNetworkInterfaces)
+	for _, n := range interfaces {
+		for category, value := range n.categories() {
+			o.set(category, value)
+		}
+	}
+
+	primary := interfaces[0]
+	for _, n := range interfaces {
+		if n.DeviceNumber == 0 {
+			primary = n
+			break
+		}
+	}
+
+	o.set(PathMAC, primary.MAC)
+	if len(primary.PrivateIPv4s) > 0 {
+		o.set(PathLocalIPv4, primary.PrivateIPv4s[0])
+	}
+
+	if len(primary.PublicIPv4s) > 0 {
+		o.set(pathPublicIPv4, primary.PublicIPv4s[0])
+	}
+
+	return nil
+}
+
+// validateNetworkInterfaces ensures every network interface has a valid MAC address, and
+// that both its MAC address and device number are unique
+func validateNetworkInterfaces(interfaces []NetworkInterface) error {
+	macs := map[string]struct{}{}
+	deviceNumbers := map[int]struct{}{}
+	for _, n := range interfaces {
+		mac, err := net.ParseMAC(n.MAC)
+		if err != nil {
+			return fmt.Errorf("network interface has an invalid MAC address: %w", err)
+		}
+
+		if _, found := macs[mac.String()]; found {
+			return fmt.Errorf("network interface MAC address %s is not unique", n.MAC)
+		}
+		macs[mac.String()] = struct{}{}
+
+		if _, found := deviceNumbers[n.DeviceNumber]; found {
+			return fmt.Errorf("network interface device number %d is not unique", n.DeviceNumber)
+		}
+		deviceNumbers[n.DeviceNumber] = struct{}{}
+	}
+
+	return nil
+}
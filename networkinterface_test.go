@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkInterfacePath(t *testing.T) {
+	assert.Equal(t, imds.PathNetworkInterfaces0LocalIPv4s, imds.NetworkInterfacePath(imds.ValueMAC, imds.InterfaceFieldLocalIPv4s))
+}
+
+func TestStartServer_NetworkInterfaces(t *testing.T) {
+	server := startServer(t, imds.Options{
+		RandomPort: true,
+		NetworkInterfaces: []imds.NetworkInterface{
+			{
+				MAC:                 "0a:1b:2c:3d:4e:5f",
+				InterfaceID:         "eni-0123456789abcdef0",
+				PrivateIPv4s:        []string{"10.0.1.10", "10.0.1.11"},
+				PublicIPv4s:         []string{"54.1.2.3"},
+				SecurityGroupIDs:    []string{"sg-01", "sg-02"},
+				SubnetIPv4CIDRBlock: "10.0.1.0/24",
+				VPCIPv4CIDRBlocks:   []string{"10.0.0.0/16"},
+			},
+			{
+				MAC:          "0a:1b:2c:3d:4e:60",
+				DeviceNumber: 1,
+				PrivateIPv4s: []string{"10.0.2.10"},
+				IPv6s:        []string{"2a05:d01c:f2d:3200::1"},
+			},
+		},
+	})
+
+	macs, _, err := server.Get("network/interfaces/macs/")
+	require.NoError(t, err)
+	assert.Equal(t, "0a:1b:2c:3d:4e:5f/\n0a:1b:2c:3d:4e:60/", macs)
+
+	t.Run("PrimaryInterface", func(t *testing.T) {
+		mac, err := server.MAC(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "0a:1b:2c:3d:4e:5f", mac.String())
+
+		ip, err := server.LocalIPv4(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.1.10", ip.String())
+
+		subnet, err := server.SubnetIPv4CIDRBlock(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, netip.MustParsePrefix("10.0.1.0/24"), subnet)
+
+		out, _, err := server.Get("public-ipv4")
+		require.NoError(t, err)
+		assert.Equal(t, "54.1.2.3", out)
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		out, _, err := server.Get(imds.NetworkInterfacePath("0a:1b:2c:3d:4e:5f", imds.InterfaceFieldSecurityGroupIDs))
+		require.NoError(t, err)
+		assert.Equal(t, "sg-01\nsg-02", out)
+
+		out, _, err = server.Get(imds.NetworkInterfacePath("0a:1b:2c:3d:4e:5f", imds.InterfaceFieldIPv4Associations+"/54.1.2.3"))
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.1.10", out)
+
+		out, _, err = server.Get(imds.NetworkInterfacePath("0a:1b:2c:3d:4e:60", imds.InterfaceFieldIPv6s))
+		require.NoError(t, err)
+		assert.Equal(t, "2a05:d01c:f2d:3200::1", out)
+	})
+
+	t.Run("MockInterfaceRemoved", func(t *testing.T) {
+		_, _, err := server.Get(imds.PathNetworkInterfaces0InterfaceID)
+		assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+	})
+}
+
+func TestStartServer_NetworkInterfacesInvalidMAC(t *testing.T) {
+	_, err := imds.StartServer(context.Background(), imds.Options{
+		RandomPort:        true,
+		NetworkInterfaces: []imds.NetworkInterface{{MAC: "invalid"}},
+	})
+	require.Error(t, err)
+}
+
+func TestStartServer_NetworkInterfacesDuplicateMAC(t *testing.T) {
+	_, err := imds.StartServer(context.Background(), imds.Options{
+		RandomPort: true,
+		NetworkInterfaces: []imds.NetworkInterface{
+			{MAC: "0e:49:61:0f:c3:11", DeviceNumber: 0},
+			{MAC: "0E:49:61:0F:C3:11", DeviceNumber: 1},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestStartServer_NetworkInterfacesDuplicateDeviceNumber(t *testing.T) {
+	_, err := imds.StartServer(context.Background(), imds.Options{
+		RandomPort: true,
+		NetworkInterfaces: []imds.NetworkInterface{
+			{MAC: "0e:49:61:0f:c3:11", DeviceNumber: 1},
+			{MAC: "0e:49:61:0f:c3:12", DeviceNumber: 1},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestStartWith_NetworkInterfacesDuplicateMAC(t *testing.T) {
+	_, err := imds.StartWith(context.Background(), imds.Options{
+		NetworkInterfaces: []imds.NetworkInterface{
+			{MAC: "0e:49:61:0f:c3:11", DeviceNumber: 0},
+			{MAC: "0e:49:61:0f:c3:11", DeviceNumber: 1},
+		},
+	})
+	require.Error(t, err)
+}
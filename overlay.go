@@ -0,0 +1,414 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	metadataPrefix = "/latest/meta-data/"
+	userDataPath   = "/latest/user-data"
+	tokenPath      = "/latest/api/token"
+
+	// tokenTTLHeader reports the TTL of a session token in seconds
+	tokenTTLHeader = "X-aws-ec2-metadata-token-ttl-seconds"
+
+	notFound = `<?xml version="1.0" encoding="iso-8859-1"?>
+<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN"
+	"http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
+<html xmlns="http://www.w3.org/1999/xhtml" xml:lang="en" lang="en">
+ <head>
+  <title>404 - Not Found</title>
+ </head>
+ <body>
+  <h1>404 - Not Found</h1>
+ </body>
+</html>`
+)
+
+// overlay serves instance metadata on top of an upstream imds-mock, allowing categories
+// to be overridden or added without any support from the mock itself. The upstream mock
+// remains responsible for issuing session tokens and authorising all requests. If replace
+// is set, the instance metadata of the upstream mock is ignored entirely. Any hidden
+// category, along with its descendants, is removed from the upstream mock
+type overlay struct {
+	upstream  http.Handler
+	pretty    bool
+	replace   bool
+	values    map[string]string
+	generated map[string]func() string
+	hidden    map[string]struct{}
+	raw       map[string][]byte
+	mu        sync.RWMutex
+}
+
+func newOverlay(upstream http.Handler, pretty bool) *overlay {
+	return &overlay{
+		upstream:  upstream,
+		pretty:    pretty,
+		values:    map[string]string{},
+		generated: map[string]func() string{},
+		hidden:    map[string]struct{}{},
+		raw:       map[string][]byte{},
+	}
+}
+
+// set the value of an instance category, overwriting any existing value
+func (o *overlay) set(category, value string) {
+	o.mu.Lock()
+	o.values[strings.Trim(category, "/")] = value
+	o.mu.Unlock()
+}
+
+// generate the value of an instance category on each request, overwriting any
+// existing value
+func (o *overlay) generate(category string, fn func() string) {
+	o.mu.Lock()
+	o.generated[strings.Trim(category, "/")] = fn
+	o.mu.Unlock()
+}
+
+// hide an instance category of the upstream mock, along with all of its descendants.
+// Categories within the overlay are unaffected
+func (o *overlay) hide(category string) {
+	o.mu.Lock()
+	o.hidden[strings.Trim(category, "/")] = struct{}{}
+	o.mu.Unlock()
+}
+
+// remove an instance category, along with all of its descendants, from both the overlay
+// and the upstream mock
+func (o *overlay) remove(category string) {
+	o.replaceTree(category, nil)
+}
+
+// replaceTree atomically replaces an instance category, along with all of its descendants,
+// with the given set of categories. The upstream mock is hidden for the category
+func (o *overlay) replaceTree(category string, values map[string]string) {
+	category = strings.Trim(category, "/")
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for path := range o.values {
+		if path == category || strings.HasPrefix(path, category+"/") {
+			delete(o.values, path)
+		}
+	}
+
+	for path := range o.generated {
+		if path == category || strings.HasPrefix(path, category+"/") {
+			delete(o.generated, path)
+		}
+	}
+
+	o.hidden[category] = struct{}{}
+	for path, value := range values {
+		o.values[strings.Trim(path, "/")] = value
+	}
+}
+
+// lookup the value of an instance category within the overlay
+func (o *overlay) lookup(category string) (string, bool) {
+	if value, found := o.values[category]; found {
+		return value, true
+	}
+
+	if fn, found := o.generated[category]; found {
+		return fn(), true
+	}
+
+	return "", false
+}
+
+// isHidden reports whether the instance category, or any of its ancestors, is hidden
+func (o *overlay) isHidden(category string) bool {
+	for hidden := range o.hidden {
+		if category == hidden || strings.HasPrefix(category, hidden+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setRaw sets the data served verbatim for a path outside of the metadata endpoint,
+// such as user-data
+func (o *overlay) setRaw(path string, data []byte) {
+	o.mu.Lock()
+	o.raw[path] = data
+	o.mu.Unlock()
+}
+
+func (o *overlay) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPut && r.URL.Path == tokenPath {
+		o.serveToken(w, r)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		o.upstream.ServeHTTP(w, r)
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	o.mu.RLock()
+	data, found := o.raw[path]
+	rawChildren := childrenOf(path, o.raw)
+	o.mu.RUnlock()
+
+	if found {
+		if !o.authorise(w, r) {
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+		return
+	}
+
+	if len(rawChildren) > 0 && strings.HasPrefix(path, "/latest/") {
+		if !o.authorise(w, r) {
+			return
+		}
+		writeListing(w, rawChildren)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path+"/", metadataPrefix) {
+		o.upstream.ServeHTTP(w, r)
+		return
+	}
+
+	category := strings.Trim(strings.TrimPrefix(r.URL.Path+"/", metadataPrefix), "/")
+
+	o.mu.RLock()
+	value, found := o.lookup(category)
+	children := childrenOf(category, o.values)
+	for name := range childrenOf(category, o.generated) {
+		children[name] = struct{}{}
+	}
+	hidden := o.isHidden(category)
+	hiddenChildren := childrenOf(category, o.hidden)
+	o.mu.RUnlock()
+
+	switch {
+	case found:
+		if !o.authorise(w, r) {
+			return
+		}
+		o.writeValue(w, value)
+	case len(children) > 0 || (len(hiddenChildren) > 0 && !hidden):
+		o.serveListing(w, r, children, hiddenChildren, hidden)
+	case o.replace || hidden:
+		if !o.authorise(w, r) {
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(notFound))
+	default:
+		o.upstream.ServeHTTP(w, r)
+	}
+}
+
+// childrenOf returns the names of all paths that reside directly under the given parent
+// path. Any child that is itself a parent is suffixed with a slash
+func childrenOf[V any](parent string, paths map[string]V) map[string]struct{} {
+	prefix := ""
+	if parent != "" {
+		prefix = parent + "/"
+	}
+
+	children := map[string]struct{}{}
+	for category := range paths {
+		if !strings.HasPrefix(category, prefix) {
+			continue
+		}
+
+		name, rest, nested := strings.Cut(strings.TrimPrefix(category, prefix), "/")
+		if nested && rest != "" {
+			name += "/"
+		}
+		children[name] = struct{}{}
+	}
+
+	return children
+}
+
+// serveListing merges the listing of a parent category from the upstream mock with any
+// categories provided by the overlay, excluding any hidden categories of the mock. If
+// the parent category is itself hidden, only categories from the overlay are listed
+func (o *overlay) serveListing(w http.ResponseWriter, r *http.Request, children, hidden map[string]struct{}, hideAll bool) {
+	if o.replace || hideAll {
+		if !o.authorise(w, r) {
+			return
+		}
+		writeListing(w, children)
+		return
+	}
+
+	rec := o.forward(r, r.URL.Path)
+
+	switch rec.Code {
+	case http.StatusOK:
+		for _, name := range strings.Split(rec.Body.String(), "\n") {
+			if name == "" {
+				continue
+			}
+
+			// Avoid duplicating a category that exists within both the mock and the overlay
+			if _, exists := children[strings.TrimSuffix(name, "/")]; exists {
+				continue
+			}
+			if _, exists := children[strings.TrimSuffix(name, "/")+"/"]; exists {
+				continue
+			}
+
+			if isHiddenName(name, hidden) {
+				continue
+			}
+			children[name] = struct{}{}
+		}
+	case http.StatusNotFound:
+		// Parent category only exists within the overlay, but still requires authorisation
+		if !o.authorise(w, r) {
+			return
+		}
+	default:
+		copyResponse(w, rec)
+		return
+	}
+
+	writeListing(w, children)
+}
+
+// isHiddenName reports whether a name within a listing has been hidden. Only a hidden
+// name that is not itself a parent, hides the name entirely
+func isHiddenName(name string, hidden map[string]struct{}) bool {
+	_, exists := hidden[strings.TrimSuffix(name, "/")]
+	return exists
+}
+
+func writeListing(w http.ResponseWriter, children map[string]struct{}) {
+	names := make([]string, 0, len(children))
+	for name := range children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(strings.Join(names, "\n")))
+}
+
+// authorise the request against the upstream mock, ensuring any value served by the overlay
+// is subject to the same IMDSv2 rules. The rejected response is written if unauthorised
+func (o *overlay) authorise(w http.ResponseWriter, r *http.Request) bool {
+	rec := o.forward(r, metadataPrefix)
+	if rec.Code == http.StatusOK {
+		return true
+	}
+
+	copyResponse(w, rec)
+	return false
+}
+
+// serveToken issues a session token through the upstream mock. Unlike IMDS, the mock
+// does not report the TTL of the token through a response header, which the AWS SDKs
+// depend upon
+func (o *overlay) serveToken(w http.ResponseWriter, r *http.Request) {
+	rec := o.forward(r, tokenPath)
+	if rec.Code == http.StatusOK && rec.Header().Get(tokenTTLHeader) == "" {
+		rec.Header().Set(tokenTTLHeader, r.Header.Get(tokenTTLHeader))
+	}
+
+	copyResponse(w, rec)
+}
+
+// forward a copy of the request to the upstream mock for the given path, recording
+// the response
+func (o *overlay) forward(r *http.Request, path string) *httptest.ResponseRecorder {
+	req := r.Clone(r.Context())
+	req.URL.Path = path
+	req.URL.RawPath = ""
+	req.RequestURI = ""
+
+	rec := httptest.NewRecorder()
+	o.upstream.ServeHTTP(rec, req)
+	return rec
+}
+
+func (o *overlay) writeValue(w http.ResponseWriter, value string) {
+	if o.pretty && json.Valid([]byte(value)) {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, []byte(value), "", "  "); err == nil {
+			value = buf.String()
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(value))
+}
+
+func copyResponse(w http.ResponseWriter, rec *httptest.ResponseRecorder) {
+	for key, values := range rec.Header() {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(rec.Code)
+	w.Write(rec.Body.Bytes())
+}
+
+// serveOverlay starts an HTTP server on the host that serves the overlay. If no port
+// is provided, an ephemeral port will be allocated. The endpoint of the server is
+// returned upon success
+func serveOverlay(o *overlay, port string) (*http.Server, string, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort("localhost", port))
+	if err != nil {
+		return nil, "", err
+	}
+
+	srv := &http.Server{Handler: o}
+	go srv.Serve(listener)
+
+	_, listenerPort, _ := net.SplitHostPort(listener.Addr().String())
+	return srv, "http://" + net.JoinHostPort("localhost", listenerPort), nil
+}
+
+// reverseProxy forwards all requests to the given endpoint
+func reverseProxy(endpoint string) http.Handler {
+	target, _ := url.Parse(endpoint)
+	return httputil.NewSingleHostReverseProxy(target)
+}
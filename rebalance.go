@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// scheduleRebalanceRecommendation raises a rebalance recommendation once the delay has
+// elapsed, hiding any recommendation raised by the mock until then
+func (c *client) scheduleRebalanceRecommendation(delay time.Duration) error {
+	recommendation, err := marshalRebalanceRecommendation(time.Now().Add(delay))
+	if err != nil {
+		return err
+	}
+
+	c.overlay.remove(PathEventsRecommendationsRebalance)
+	if delay <= 0 {
+		c.overlay.set(PathEventsRecommendationsRebalance, recommendation)
+		return nil
+	}
+
+	c.rebalanceMu.Lock()
+	defer c.rebalanceMu.Unlock()
+
+	c.rebalance = time.AfterFunc(delay, func() {
+		c.rebalanceMu.Lock()
+		defer c.rebalanceMu.Unlock()
+
+		// Ignore a recommendation that was cancelled while the timer was firing
+		if c.rebalance != nil {
+			c.overlay.set(PathEventsRecommendationsRebalance, recommendation)
+			c.rebalance = nil
+		}
+	})
+	return nil
+}
+
+func marshalRebalanceRecommendation(noticeTime time.Time) (string, error) {
+	recommendation, err := json.Marshal(RebalanceRecommendation{
+		NoticeTime: noticeTime.UTC().Truncate(time.Second),
+	})
+	return string(recommendation), err
+}
+
+// stopRebalanceRecommendation cancels any rebalance recommendation yet to be raised
+// through the Rebalance option. The caller must hold rebalanceMu
+func (c *client) stopRebalanceRecommendation() {
+	if c.rebalance != nil {
+		c.rebalance.Stop()
+		c.rebalance = nil
+	}
+}
+
+// cancelRebalanceRecommendation cancels any rebalance recommendation yet to be raised
+// through the Rebalance option, ensuring it is never raised after the overlay is closed
+func (c *client) cancelRebalanceRecommendation() {
+	c.rebalanceMu.Lock()
+	c.stopRebalanceRecommendation()
+	c.rebalanceMu.Unlock()
+}
+
+// TriggerRebalanceRecommendation raises a rebalance recommendation signal at runtime,
+// exposing the events/recommendations/rebalance category with the given notice time.
+// Any existing recommendation, including one scheduled through the Rebalance option,
+// is replaced. ErrNotMutable is returned if the container was not started with Mutable
+func (c *client) TriggerRebalanceRecommendation(noticeTime time.Time) error {
+	if !c.mutable {
+		return ErrNotMutable
+	}
+
+	recommendation, err := marshalRebalanceRecommendation(noticeTime)
+	if err != nil {
+		return err
+	}
+
+	c.rebalanceMu.Lock()
+	defer c.rebalanceMu.Unlock()
+
+	c.stopRebalanceRecommendation()
+	c.overlay.remove(PathEventsRecommendationsRebalance)
+	c.overlay.set(PathEventsRecommendationsRebalance, recommendation)
+	return nil
+}
+
+// ClearRebalanceRecommendation withdraws any rebalance recommendation signal at runtime,
+// removing the events/recommendations/rebalance category. ErrNotMutable is returned if
+// the container was not started with Mutable
+func (c *client) ClearRebalanceRecommendation() error {
+	if !c.mutable {
+		return ErrNotMutable
+	}
+
+	c.rebalanceMu.Lock()
+	defer c.rebalanceMu.Unlock()
+
+	c.stopRebalanceRecommendation()
+	c.overlay.remove(PathEventsRecommendationsRebalance)
+	return nil
+}
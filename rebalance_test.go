@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartServer_Rebalance(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Rebalance: true})
+
+	recommendation, err := server.RebalanceRecommendation(context.Background())
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), recommendation.NoticeTime, 5*time.Second)
+}
+
+func TestStartServer_RebalanceDelay(t *testing.T) {
+	server := startServer(t, imds.Options{
+		RandomPort:     true,
+		Rebalance:      true,
+		RebalanceDelay: 500 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	_, err := server.RebalanceRecommendation(ctx)
+	require.ErrorIs(t, err, imds.ErrCategoryNotFound)
+
+	assert.Eventually(t, func() bool {
+		_, err := server.RebalanceRecommendation(ctx)
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestTriggerRebalanceRecommendation(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+
+	_, err := server.RebalanceRecommendation(ctx)
+	require.ErrorIs(t, err, imds.ErrCategoryNotFound)
+
+	noticeTime := time.Date(2023, time.March, 14, 9, 30, 0, 0, time.UTC)
+	require.NoError(t, server.TriggerRebalanceRecommendation(noticeTime))
+
+	recommendation, err := server.RebalanceRecommendation(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, noticeTime, recommendation.NoticeTime)
+
+	_, err = server.SpotInstanceAction(ctx)
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+}
+
+func TestTriggerRebalanceRecommendation_NotMutable(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	err := server.TriggerRebalanceRecommendation(time.Now())
+	assert.ErrorIs(t, err, imds.ErrNotMutable)
+}
+
+func TestClearRebalanceRecommendation(t *testing.T) {
+	server := startServer(t, imds.Options{
+		RandomPort:     true,
+		Rebalance:      true,
+		RebalanceDelay: 50 * time.Millisecond,
+		Mutable:        true,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, server.ClearRebalanceRecommendation())
+
+	assert.Never(t, func() bool {
+		_, err := server.RebalanceRecommendation(ctx)
+		return err == nil
+	}, 250*time.Millisecond, 25*time.Millisecond)
+}
@@ -0,0 +1,282 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/purpleclay/imds-mock/pkg/imds/patch"
+)
+
+// Scenario describes the lifetime of an instance as a timeline of steps, with each
+// step changing the instance metadata at a set offset from launch. A scenario is
+// played against a virtual clock, which must be advanced manually, ensuring tests
+// remain deterministic:
+//
+//	imds.Scenario{
+//		Steps: []imds.ScenarioStep{
+//			{At: 30 * time.Second, Action: imds.SetTagsAction(map[string]string{"Name": "draining"})},
+//			{At: 60 * time.Second, Action: imds.RebalanceRecommendationAction()},
+//			{At: 90 * time.Second, Action: imds.SpotInterruptionAction(patch.StopSpotInstanceAction)},
+//		},
+//	}
+type Scenario struct {
+	// Start is the virtual time at which the instance launched. Actions are passed
+	// the current virtual time, from which any timestamps they set are derived. All
+	// other timestamps within the instance metadata use the real clock
+	//	@Default time.Now()
+	Start time.Time
+
+	// Steps to apply as the virtual clock is advanced. Steps are applied in order of
+	// their offset, with those sharing an offset applied in the order defined
+	Steps []ScenarioStep
+}
+
+// ScenarioStep changes the instance metadata at a set offset from launch
+type ScenarioStep struct {
+	// At is the offset from launch at which the step is applied
+	At time.Duration
+
+	// Action to apply
+	Action ScenarioAction
+}
+
+// Mutator changes the instance metadata at runtime. It is satisfied by both
+// Container and Server, allowing custom scenario actions to be written
+type Mutator interface {
+	SetValue(category, value string) error
+	SetTags(tags map[string]string) error
+	Delete(category string) error
+	TriggerSpotInterruption(action patch.SpotInstanceAction, terminationTime time.Time) error
+	ClearSpotInterruption() error
+	TriggerRebalanceRecommendation(noticeTime time.Time) error
+	ClearRebalanceRecommendation() error
+	ScheduleMaintenanceEvent(event MaintenanceEvent) (MaintenanceEvent, error)
+	CompleteMaintenanceEvent(eventID string) error
+	CancelMaintenanceEvent(eventID string) error
+	SetTargetLifecycleState(state TargetLifecycleState) error
+}
+
+// ScenarioAction changes the instance metadata when a step within a scenario is reached.
+// The current virtual time is provided for deriving any timestamps
+type ScenarioAction func(ctx context.Context, m Mutator, now time.Time) error
+
+// SetValueAction sets the value of an instance category, see SetValue
+func SetValueAction(category, value string) ScenarioAction {
+	return func(_ context.Context, m Mutator, _ time.Time) error {
+		return m.SetValue(category, value)
+	}
+}
+
+// SetTagsAction replaces all instance tags, see SetTags
+func SetTagsAction(tags map[string]string) ScenarioAction {
+	return func(_ context.Context, m Mutator, _ time.Time) error {
+		return m.SetTags(tags)
+	}
+}
+
+// DeleteAction removes an instance category, see Delete
+func DeleteAction(category string) ScenarioAction {
+	return func(_ context.Context, m Mutator, _ time.Time) error {
+		return m.Delete(category)
+	}
+}
+
+// SpotInterruptionAction raises a spot interruption notice, see TriggerSpotInterruption.
+// In the best case, IMDS raises a notice two minutes in advance, and the termination
+// time is set accordingly. A hibernate action takes place immediately
+func SpotInterruptionAction(action patch.SpotInstanceAction) ScenarioAction {
+	return func(_ context.Context, m Mutator, now time.Time) error {
+		if action != patch.HibernateSpotInstanceAction {
+			now = now.Add(2 * time.Minute)
+		}
+
+		return m.TriggerSpotInterruption(action, now)
+	}
+}
+
+// ClearSpotInterruptionAction withdraws a spot interruption notice, see ClearSpotInterruption
+func ClearSpotInterruptionAction() ScenarioAction {
+	return func(_ context.Context, m Mutator, _ time.Time) error {
+		return m.ClearSpotInterruption()
+	}
+}
+
+// RebalanceRecommendationAction raises a rebalance recommendation signal, with a notice
+// time matching the virtual clock, see TriggerRebalanceRecommendation
+func RebalanceRecommendationAction() ScenarioAction {
+	return func(_ context.Context, m Mutator, now time.Time) error {
+		return m.TriggerRebalanceRecommendation(now)
+	}
+}
+
+// ClearRebalanceRecommendationAction withdraws a rebalance recommendation signal,
+// see ClearRebalanceRecommendation
+func ClearRebalanceRecommendationAction() ScenarioAction {
+	return func(_ context.Context, m Mutator, _ time.Time) error {
+		return m.ClearRebalanceRecommendation()
+	}
+}
+
+// ScheduleMaintenanceEventAction injects a maintenance event, see ScheduleMaintenanceEvent.
+// An EventID must be provided if the event is to be completed or canceled by a later step
+func ScheduleMaintenanceEventAction(event MaintenanceEvent) ScenarioAction {
+	return func(_ context.Context, m Mutator, _ time.Time) error {
+		_, err := m.ScheduleMaintenanceEvent(event)
+		return err
+	}
+}
+
+// CompleteMaintenanceEventAction moves a scheduled maintenance event into history,
+// see CompleteMaintenanceEvent
+func CompleteMaintenanceEventAction(eventID string) ScenarioAction {
+	return func(_ context.Context, m Mutator, _ time.Time) error {
+		return m.CompleteMaintenanceEvent(eventID)
+	}
+}
+
+// CancelMaintenanceEventAction moves a scheduled maintenance event into history,
+// see CancelMaintenanceEvent
+func CancelMaintenanceEventAction(eventID string) ScenarioAction {
+	return func(_ context.Context, m Mutator, _ time.Time) error {
+		return m.CancelMaintenanceEvent(eventID)
+	}
+}
+
+// TargetLifecycleStateAction transitions the target lifecycle state of the instance,
+// see SetTargetLifecycleState
+func TargetLifecycleStateAction(state TargetLifecycleState) ScenarioAction {
+	return func(_ context.Context, m Mutator, _ time.Time) error {
+		return m.SetTargetLifecycleState(state)
+	}
+}
+
+// ScenarioClock is a virtual clock that plays a scenario against the instance metadata.
+// Steps are only applied when the clock is advanced
+type ScenarioClock struct {
+	client  *client
+	steps   []ScenarioStep
+	start   time.Time
+	elapsed time.Duration
+	next    int
+	mu      sync.Mutex
+}
+
+// PlayScenario plays a scenario against the instance metadata, applying any steps due
+// at launch. The returned clock must be advanced to apply all remaining steps.
+// ErrNotMutable is returned if the container was not started with Mutable. Every step
+// must have an action, otherwise an error is returned before any step is applied
+func (c *client) PlayScenario(ctx context.Context, scenario Scenario) (*ScenarioClock, error) {
+	if !c.mutable {
+		return nil, ErrNotMutable
+	}
+
+	for i, step := range scenario.Steps {
+		if step.Action == nil {
+			return nil, fmt.Errorf("scenario step %d at %s has no action", i, step.At)
+		}
+	}
+
+	steps := make([]ScenarioStep, len(scenario.Steps))
+	copy(steps, scenario.Steps)
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].At < steps[j].At
+	})
+
+	start := scenario.Start
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	clock := &ScenarioClock{client: c, steps: steps, start: start}
+	if err := clock.Advance(ctx, 0); err != nil {
+		return nil, err
+	}
+
+	return clock, nil
+}
+
+// Scenario returns the virtual clock of the scenario played at startup. Nil is returned
+// if no scenario was provided
+func (c *client) Scenario() *ScenarioClock {
+	return c.scenario
+}
+
+// Now returns the current virtual time
+func (s *ScenarioClock) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.start.Add(s.elapsed)
+}
+
+// Elapsed returns the virtual time elapsed since launch
+func (s *ScenarioClock) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.elapsed
+}
+
+// Done reports whether every step within the scenario has been applied
+func (s *ScenarioClock) Done() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.next == len(s.steps)
+}
+
+// Advance moves the virtual clock forward, applying all steps that fall due in order.
+// If a step fails, the clock stops at the offset of that step, and the step will be
+// retried when the clock is next advanced. ErrClockBackwards is returned if d is negative
+func (s *ScenarioClock) Advance(ctx context.Context, d time.Duration) error {
+	if d < 0 {
+		return ErrClockBackwards
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target := s.elapsed + d
+	for ; s.next < len(s.steps); s.next++ {
+		step := s.steps[s.next]
+		if step.At > target {
+			break
+		}
+
+		if step.At > s.elapsed {
+			s.elapsed = step.At
+		}
+
+		if err := step.Action(ctx, s.client, s.start.Add(s.elapsed)); err != nil {
+			return fmt.Errorf("scenario step %d at %s failed: %w", s.next, step.At, err)
+		}
+	}
+
+	s.elapsed = target
+	return nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/purpleclay/imds-mock/pkg/imds/patch"
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var launch = time.Date(2023, time.March, 14, 9, 0, 0, 0, time.UTC)
+
+func TestStartServer_Scenario(t *testing.T) {
+	server := startServer(t, imds.Options{
+		RandomPort: true,
+		Scenario: &imds.Scenario{
+			Start: launch,
+			Steps: []imds.ScenarioStep{
+				{At: 90 * time.Second, Action: imds.SpotInterruptionAction(patch.StopSpotInstanceAction)},
+				{At: 0, Action: imds.SetValueAction(imds.PathInstanceType, "m7g.large")},
+				{At: 30 * time.Second, Action: imds.SetTagsAction(map[string]string{"Name": "draining"})},
+				{At: 60 * time.Second, Action: imds.RebalanceRecommendationAction()},
+			},
+		},
+	})
+	ctx := context.Background()
+	clock := server.Scenario()
+	require.NotNil(t, clock)
+
+	out, _, err := server.Get(imds.PathInstanceType)
+	require.NoError(t, err)
+	assert.Equal(t, "m7g.large", out)
+
+	require.NoError(t, clock.Advance(ctx, 30*time.Second))
+	out, _, err = server.Get(imds.InstanceTagPath("Name"))
+	require.NoError(t, err)
+	assert.Equal(t, "draining", out)
+
+	_, err = server.RebalanceRecommendation(ctx)
+	require.ErrorIs(t, err, imds.ErrCategoryNotFound)
+
+	require.NoError(t, clock.Advance(ctx, 45*time.Second))
+	assert.Equal(t, 75*time.Second, clock.Elapsed())
+
+	recommendation, err := server.RebalanceRecommendation(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, launch.Add(60*time.Second), recommendation.NoticeTime)
+	assert.False(t, clock.Done())
+
+	require.NoError(t, clock.Advance(ctx, 15*time.Second))
+	action, err := server.SpotInstanceAction(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, patch.StopSpotInstanceAction, action.Action)
+	assert.Equal(t, launch.Add(90*time.Second+2*time.Minute), action.Time)
+	assert.True(t, clock.Done())
+}
+
+func TestPlayScenario(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+	assert.Nil(t, server.Scenario())
+
+	clock, err := server.PlayScenario(ctx, imds.Scenario{
+		Start: launch,
+		Steps: []imds.ScenarioStep{
+			{At: time.Minute, Action: imds.ScheduleMaintenanceEventAction(imds.MaintenanceEvent{
+				Code:    imds.MaintenanceEventCodeSystemReboot,
+				EventID: "instance-event-0d59937288b749b32",
+			})},
+			{At: 2 * time.Minute, Action: imds.CompleteMaintenanceEventAction("instance-event-0d59937288b749b32")},
+			{At: 2 * time.Minute, Action: imds.TargetLifecycleStateAction(imds.TargetLifecycleStateTerminated)},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, launch, clock.Now())
+
+	require.NoError(t, clock.Advance(ctx, time.Minute))
+	events, err := server.ScheduledMaintenanceEvents(ctx)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	require.NoError(t, clock.Advance(ctx, time.Minute))
+	history, err := server.MaintenanceEventHistory(ctx)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	state, err := server.TargetLifecycleState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, imds.TargetLifecycleStateTerminated, state)
+}
+
+func TestPlayScenario_NotMutable(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	_, err := server.PlayScenario(context.Background(), imds.Scenario{})
+	assert.ErrorIs(t, err, imds.ErrNotMutable)
+}
+
+func TestPlayScenario_NilAction(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+
+	_, err := server.PlayScenario(ctx, imds.Scenario{
+		Steps: []imds.ScenarioStep{
+			{At: 0, Action: imds.SetValueAction(imds.PathInstanceType, "m7g.large")},
+			{At: time.Minute},
+		},
+	})
+	require.Error(t, err)
+
+	out, _, err := server.Get(imds.PathInstanceType)
+	require.NoError(t, err)
+	assert.Equal(t, imds.ValueInstanceType, out)
+}
+
+func TestStartServer_ScenarioNilAction(t *testing.T) {
+	_, err := imds.StartServer(context.Background(), imds.Options{
+		RandomPort: true,
+		Scenario: &imds.Scenario{
+			Steps: []imds.ScenarioStep{{At: time.Minute}},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestScenarioClock_AdvanceFails(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+
+	clock, err := server.PlayScenario(ctx, imds.Scenario{
+		Start: launch,
+		Steps: []imds.ScenarioStep{
+			{At: 30 * time.Second, Action: imds.CancelMaintenanceEventAction("instance-event-0d59937288b749b32")},
+		},
+	})
+	require.NoError(t, err)
+
+	err = clock.Advance(ctx, time.Minute)
+	require.ErrorIs(t, err, imds.ErrMaintenanceEventNotFound)
+	assert.Equal(t, 30*time.Second, clock.Elapsed())
+	assert.False(t, clock.Done())
+}
+
+func TestScenarioClock_AdvanceBackwards(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+
+	clock, err := server.PlayScenario(ctx, imds.Scenario{Start: launch})
+	require.NoError(t, err)
+	require.NoError(t, clock.Advance(ctx, time.Minute))
+
+	err = clock.Advance(ctx, -30*time.Second)
+	require.ErrorIs(t, err, imds.ErrClockBackwards)
+	assert.Equal(t, time.Minute, clock.Elapsed())
+}
+
+func TestScenario_ClearActions(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+
+	clock, err := server.PlayScenario(ctx, imds.Scenario{
+		Start: launch,
+		Steps: []imds.ScenarioStep{
+			{At: 0, Action: imds.SpotInterruptionAction(patch.TerminateSpotInstanceAction)},
+			{At: 0, Action: imds.RebalanceRecommendationAction()},
+			{At: time.Minute, Action: imds.ClearSpotInterruptionAction()},
+			{At: time.Minute, Action: imds.ClearRebalanceRecommendationAction()},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = server.SpotInstanceAction(ctx)
+	require.NoError(t, err)
+	_, err = server.RebalanceRecommendation(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, clock.Advance(ctx, time.Minute))
+
+	_, err = server.SpotInstanceAction(ctx)
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+	_, err = server.RebalanceRecommendation(ctx)
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+}
+
+func TestScenario_CustomAction(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+
+	custom := func(_ context.Context, m imds.Mutator, now time.Time) error {
+		return m.SetTags(map[string]string{"LaunchedAt": now.Format(time.RFC3339)})
+	}
+
+	_, err := server.PlayScenario(ctx, imds.Scenario{
+		Start: launch,
+		Steps: []imds.ScenarioStep{
+			{At: 0, Action: custom},
+		},
+	})
+	require.NoError(t, err)
+
+	out, _, err := server.Get(imds.InstanceTagPath("LaunchedAt"))
+	require.NoError(t, err)
+	assert.Equal(t, "2023-03-14T09:00:00Z", out)
+}
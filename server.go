@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/creasty/defaults"
+	"github.com/gin-gonic/gin"
+	imdsmock "github.com/purpleclay/imds-mock/pkg/imds"
+)
+
+// releaseMode ensures gin runs in release mode, consistent with the imds-mock image
+var releaseMode sync.Once
+
+// Server represents an in-process instance of the Instance Metadata Mock (imds-mock). It
+// provides the same API as a Container, but without the need for Docker, making it suitable
+// for environments where Docker is not available
+type Server struct {
+	*client
+
+	srv *http.Server
+}
+
+// StartServer will create and start an in-process instance of the Instance Metadata Mock
+// (imds-mock), simulating the Amazon EC2 Metadata Service (IMDS). Once started, IMDS will
+// be accessible through the expected endpoint. As the caller it is your responsibility
+// to stop the server by invoking the Close() method on the server.
+//
+//	http://localhost:1338/latest/meta-data/
+//
+// All options are supported, except for those specific to Docker, which are ignored:
+// Image, ImageTag, InProcess, Network, NetworkAliases, LinkLocal and IPv6
+func StartServer(ctx context.Context, opts Options) (*Server, error) {
+	// Ensure all defaults are set before launching the server
+	if err := defaults.Set(&opts); err != nil {
+		return nil, err
+	}
+
+	if err := validateTokenTTL(opts.TokenTTL); err != nil {
+		return nil, err
+	}
+
+	// Mirror the defaults of the imds-mock CLI, which exposes a default set of instance tags
+	instanceTags := opts.InstanceTags
+	if len(instanceTags) == 0 {
+		instanceTags = imdsmock.DefaultOptions.InstanceTags
+	}
+
+	document, err := readDocumentOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseMode.Do(func() {
+		if os.Getenv(gin.EnvGinMode) == "" {
+			gin.SetMode(gin.ReleaseMode)
+		}
+	})
+
+	mock, err := imdsmock.ServeWith(imdsmock.Options{
+		ExcludeInstanceTags: opts.ExcludeInstanceTags,
+		IMDSv2:              opts.IMDSv2,
+		InstanceTags:        instanceTags,
+		Pretty:              opts.Pretty,
+		Spot:                opts.Spot,
+		SpotAction:          opts.SpotAction,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	server := &Server{client: newClient("")}
+
+	// The overlay is always used, as it is cheap to serve in-process
+	if server.srv, err = server.startOverlay(ctx, mock, document, opts); err != nil {
+		return nil, err
+	}
+
+	if opts.ManageToken {
+		server.token = newSessionToken(opts.TokenTTL)
+	}
+
+	return server, nil
+}
+
+// MustStartServer behaves in the same way as StartServer but panics if the server cannot
+// be started for any reason. This removes the need to handle any returned errors,
+// simplifying initialisation.
+//
+// As the caller it is your responsibility to stop the server by invoking the Close()
+// method on the server.
+func MustStartServer(ctx context.Context, opts Options) *Server {
+	server, err := StartServer(ctx, opts)
+	if err != nil {
+		panic(`aemm: MustStartServer(` + fmt.Sprintf("%#v", opts) + `): ` + err.Error())
+	}
+
+	return server
+}
+
+// Close will immediately stop the server
+func (s *Server) Close() error {
+	s.cancelRebalanceRecommendation()
+	return s.srv.Close()
+}
+
+// Terminate will stop the server. It is provided for parity with Container, easing the
+// switch between both
+func (s *Server) Terminate(_ context.Context) error {
+	return s.Close()
+}
@@ -0,0 +1,233 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartServer(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	out, _ := get(t, server.URL()+imds.PathInstanceID)
+	assert.Equal(t, imds.ValueInstanceID, out)
+}
+
+func TestStartServer_ExposedPort(t *testing.T) {
+	server := startServer(t, imds.Options{ExposedPort: "1340"})
+
+	assert.Equal(t, "http://localhost:1340/latest/meta-data/", server.URL())
+	assert.Equal(t, "http://localhost:1340/latest/api/token", server.TokenURL())
+}
+
+func TestMustStartServer_Panics(t *testing.T) {
+	server := startServer(t, imds.Options{ExposedPort: "1341"})
+	require.NotNil(t, server)
+
+	assert.Panics(t, func() {
+		imds.MustStartServer(context.Background(), imds.Options{ExposedPort: "1341"})
+	})
+}
+
+func TestStartServer_InstanceTags(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	out, _, err := server.Get(imds.PathTagsInstance)
+	require.NoError(t, err)
+	assert.Equal(t, imds.ValueTagsInstance, out)
+}
+
+func TestStartServer_IMDSv2(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, IMDSv2: true})
+
+	_, status := get(t, server.URL())
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	token, _, err := server.TokenWithTTL(imds.MinTokenTTLInSeconds)
+	require.NoError(t, err)
+
+	out, _, err := server.GetV2(imds.PathLocalIPv4, token)
+	require.NoError(t, err)
+	assert.Equal(t, imds.ValueLocalIPv4, out)
+}
+
+func TestStartServer_ManageToken(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, IMDSv2: true, ManageToken: true})
+
+	region, err := server.Region(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, imds.ValuePlacementRegion, region)
+}
+
+func TestStartServer_InvalidTokenTTL(t *testing.T) {
+	for _, ttl := range []time.Duration{500 * time.Millisecond, 7 * time.Hour} {
+		_, err := imds.StartServer(context.Background(), imds.Options{
+			RandomPort:  true,
+			ManageToken: true,
+			TokenTTL:    ttl,
+		})
+
+		assert.ErrorIs(t, err, imds.ErrInvalidTTL)
+	}
+}
+
+func TestStartServer_Spot(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Spot: true})
+
+	action, err := server.SpotInstanceAction(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "terminate", string(action.Action))
+}
+
+func TestStartServer_Overrides(t *testing.T) {
+	server := startServer(t, imds.Options{
+		RandomPort: true,
+		Overrides: map[string]string{
+			imds.PathInstanceType: "m7g.large",
+		},
+		UserData: []byte("#!/bin/bash"),
+	})
+
+	out, _, err := server.Get(imds.PathInstanceType)
+	require.NoError(t, err)
+	assert.Equal(t, "m7g.large", out)
+
+	userData, err := server.UserData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/bash", string(userData))
+}
+
+func TestStartServer_Document(t *testing.T) {
+	server := startServer(t, imds.Options{
+		RandomPort: true,
+		Document: strings.NewReader(`
+instance-id: i-0123456789abcdef0
+placement:
+  region: eu-west-2
+`),
+	})
+
+	snapshot, err := server.Snapshot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		imds.PathInstanceID:      "i-0123456789abcdef0",
+		imds.PathPlacementRegion: "eu-west-2",
+	}, snapshot)
+}
+
+func TestStartServer_DocumentYAMLTypes(t *testing.T) {
+	server := startServer(t, imds.Options{
+		RandomPort: true,
+		Document: strings.NewReader(`
+ami-launch-index: 0
+public-keys:
+  0:
+    openssh-key: ssh-rsa AAAA
+launched-at: 2023-03-14T09:30:00Z
+`),
+	})
+
+	snapshot, err := server.Snapshot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		imds.PathAMILaunchIndex:     "0",
+		"public-keys/0/openssh-key": "ssh-rsa AAAA",
+		"launched-at":               "2023-03-14T09:30:00Z",
+	}, snapshot)
+}
+
+func TestStartServer_DocumentLists(t *testing.T) {
+	server := startServer(t, imds.Options{
+		RandomPort: true,
+		Document: strings.NewReader(`
+security-groups: [web, db]
+events:
+  maintenance:
+    scheduled:
+      - Code: system-reboot
+        State: active
+`),
+	})
+
+	out, _, err := server.Get("security-groups")
+	require.NoError(t, err)
+	assert.Equal(t, "web\ndb", out)
+
+	out, _, err = server.Get(imds.PathEventsMaintenanceScheduled)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"Code":"system-reboot","State":"active"}]`, out)
+}
+
+func TestStartServer_InstanceIdentity(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, InstanceIdentity: true})
+
+	document, err := server.IdentityDocument(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, imds.ValueInstanceID, document.InstanceID)
+	assert.NotNil(t, server.IdentityCertificate())
+}
+
+func TestServerClose(t *testing.T) {
+	server, err := imds.StartServer(context.Background(), imds.Options{RandomPort: true})
+	require.NoError(t, err)
+	require.NoError(t, server.Close())
+
+	_, _, err = server.Get(imds.PathInstanceID)
+	assert.ErrorIs(t, err, imds.ErrConnection)
+}
+
+func startServer(t *testing.T, opts imds.Options) *imds.Server {
+	t.Helper()
+
+	server, err := imds.StartServer(context.Background(), opts)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return server
+}
+
+func TestStartServer_TokenTTLHeader(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	req, err := http.NewRequest(http.MethodPut, server.TokenURL(), http.NoBody)
+	require.NoError(t, err)
+	req.Header.Add("X-aws-ec2-metadata-token-ttl-seconds", "60")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "60", resp.Header.Get("X-aws-ec2-metadata-token-ttl-seconds"))
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/purpleclay/imds-mock/pkg/imds/patch"
+)
+
+const pathSpot = "spot"
+
+// TriggerSpotInterruption raises a spot interruption notice at runtime, exposing both
+// the spot/instance-action and spot/termination-time categories. As with IMDS, the
+// termination time is only set for a terminate action. Any existing notice, including
+// one raised through the Spot option, is replaced. ErrNotMutable is returned if the
+// container was not started with Mutable
+func (c *client) TriggerSpotInterruption(action patch.SpotInstanceAction, terminationTime time.Time) error {
+	if !c.mutable {
+		return ErrNotMutable
+	}
+
+	notice, err := json.Marshal(SpotInstanceAction{
+		Action: action,
+		Time:   terminationTime.UTC().Truncate(time.Second),
+	})
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{
+		PathSpotInstanceAction: string(notice),
+	}
+
+	if action == patch.TerminateSpotInstanceAction {
+		values[PathSpotTerminationTime] = terminationTime.UTC().Format(time.RFC3339)
+	}
+
+	c.overlay.set(PathInstanceLifecycle, "spot")
+	c.overlay.replaceTree(pathSpot, values)
+	return nil
+}
+
+// ClearSpotInterruption withdraws any spot interruption notice at runtime, removing both
+// the spot/instance-action and spot/termination-time categories. ErrNotMutable is returned
+// if the container was not started with Mutable
+func (c *client) ClearSpotInterruption() error {
+	if !c.mutable {
+		return ErrNotMutable
+	}
+
+	c.overlay.remove(pathSpot)
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/purpleclay/imds-mock/pkg/imds/patch"
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerSpotInterruption(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+
+	_, err := server.SpotInstanceAction(ctx)
+	require.ErrorIs(t, err, imds.ErrCategoryNotFound)
+
+	terminationTime := time.Date(2023, time.March, 14, 9, 30, 0, 0, time.UTC)
+	require.NoError(t, server.TriggerSpotInterruption(patch.TerminateSpotInstanceAction, terminationTime))
+
+	action, err := server.SpotInstanceAction(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, patch.TerminateSpotInstanceAction, action.Action)
+	assert.Equal(t, terminationTime, action.Time)
+
+	out, err := server.SpotTerminationTime(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, terminationTime, out)
+
+	lifecycle, _, err := server.Get(imds.PathInstanceLifecycle)
+	require.NoError(t, err)
+	assert.Equal(t, "spot", lifecycle)
+}
+
+func TestTriggerSpotInterruption_Stop(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Mutable: true})
+	ctx := context.Background()
+
+	terminationTime := time.Date(2023, time.March, 14, 9, 30, 0, 0, time.UTC)
+	require.NoError(t, server.TriggerSpotInterruption(patch.StopSpotInstanceAction, terminationTime))
+
+	action, err := server.SpotInstanceAction(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, patch.StopSpotInstanceAction, action.Action)
+
+	_, err = server.SpotTerminationTime(ctx)
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+}
+
+func TestTriggerSpotInterruption_NotMutable(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true})
+
+	err := server.TriggerSpotInterruption(patch.TerminateSpotInstanceAction, time.Now())
+	assert.ErrorIs(t, err, imds.ErrNotMutable)
+}
+
+func TestClearSpotInterruption(t *testing.T) {
+	server := startServer(t, imds.Options{RandomPort: true, Spot: true, Mutable: true})
+	ctx := context.Background()
+
+	_, err := server.SpotInstanceAction(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, server.ClearSpotInterruption())
+
+	_, err = server.SpotInstanceAction(ctx)
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+
+	_, err = server.SpotTerminationTime(ctx)
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+}
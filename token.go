@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// sessionToken caches an IMDSv2 session token, ensuring it is refreshed before it
+// expires. All access to the token is thread safe
+type sessionToken struct {
+	ttl       time.Duration
+	value     string
+	refreshAt time.Time
+	mu        sync.Mutex
+}
+
+func newSessionToken(ttl time.Duration) *sessionToken {
+	return &sessionToken{ttl: ttl}
+}
+
+// validateTokenTTL ensures the TTL of a session token, once truncated to whole seconds,
+// is within the bounds supported by IMDS
+func validateTokenTTL(ttl time.Duration) error {
+	if seconds := ttl / time.Second; seconds < MinTokenTTLInSeconds || seconds > MaxTokenTTLInSeconds {
+		return fmt.Errorf("token TTL of %s: %w", ttl, ErrInvalidTTL)
+	}
+
+	return nil
+}
+
+// get returns the cached session token, fetching a new one using the container if it
+// doesn't exist or is about to expire
+func (t *sessionToken) get(ctx context.Context, c *client) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.value != "" && time.Now().Before(t.refreshAt) {
+		return t.value, nil
+	}
+
+	token, _, err := c.TokenWithTTLContext(ctx, int(t.ttl/time.Second))
+	if err != nil {
+		return "", fmt.Errorf("session token could not be issued with a TTL of %s: %w", t.ttl, err)
+	}
+
+	// Refresh the token once 90% of its TTL has elapsed, leaving enough headroom
+	// for any in-flight requests
+	t.value = token
+	t.refreshAt = time.Now().Add(t.ttl - t.ttl/10)
+
+	return t.value, nil
+}
+
+// invalidate the cached session token, forcing a new one to be fetched on next use
+func (t *sessionToken) invalidate() {
+	t.mu.Lock()
+	t.value = ""
+	t.mu.Unlock()
+}
+
+// getManaged retrieves a path relative to the base URL, transparently attaching a
+// session token if the container manages them
+func (c *client) getManaged(ctx context.Context, base, path string) (string, int, error) {
+	if c.token == nil {
+		return c.get(ctx, base, path, "")
+	}
+
+	token, err := c.token.get(ctx, c)
+	if err != nil {
+		return "", 0, err
+	}
+
+	out, status, err := c.get(ctx, base, path, token)
+	if !errors.Is(err, ErrUnauthorized) {
+		return out, status, err
+	}
+
+	// Token was rejected, so retry once with a newly issued token
+	c.token.invalidate()
+	if token, err = c.token.get(ctx, c); err != nil {
+		return "", 0, err
+	}
+
+	return c.get(ctx, base, path, token)
+}
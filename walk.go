@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds
+
+import (
+	"context"
+	"strings"
+)
+
+// WalkFunc is invoked by Walk for every instance category that contains a value.
+// The path of the category is relative to the metadata endpoint, for example
+// placement/region. Returning an error will stop the walk
+type WalkFunc func(path, value string) error
+
+// Walk recursively traverses all instance categories under the given root, invoking
+// fn for every category that contains a value. Any category returned with a trailing
+// slash is treated as a parent category and will be traversed. Categories are visited
+// in the same order they are listed by the container. Use AllCategories as the root
+// to traverse all instance metadata
+func (c *client) Walk(ctx context.Context, root string, fn WalkFunc) error {
+	if root != AllCategories && !strings.HasSuffix(root, "/") {
+		root += "/"
+	}
+
+	return c.walk(ctx, root, fn)
+}
+
+func (c *client) walk(ctx context.Context, dir string, fn WalkFunc) error {
+	listing, err := c.value(ctx, dir)
+	if err != nil {
+		return err
+	}
+
+	// The mock treats JSON categories as parents, but returns the JSON document
+	// rather than a listing of its children
+	if isJSONObject(listing) {
+		return fn(strings.TrimSuffix(dir, "/"), listing)
+	}
+
+	for _, name := range strings.Split(listing, "\n") {
+		if name == "" {
+			continue
+		}
+
+		path := dir + name
+		if strings.HasSuffix(name, "/") {
+			if err := c.walk(ctx, path, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, err := c.value(ctx, path)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(path, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isJSONObject(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), "{")
+}
+
+// Snapshot recursively retrieves every instance category that contains a value,
+// returning them as a map keyed by the path of each category
+//
+//	placement/region: us-east-1
+func (c *client) Snapshot(ctx context.Context) (map[string]string, error) {
+	snapshot := map[string]string{}
+
+	err := c.Walk(ctx, AllCategories, func(path, value string) error {
+		snapshot[path] = value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2022 - 2023 Purple Clay
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package imds_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	imds "github.com/purpleclay/testcontainers-imds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalk(t *testing.T) {
+	container := startWithDefaults(t)
+
+	visited := map[string]string{}
+	err := container.Walk(context.Background(), "placement", func(path, value string) error {
+		visited[path] = value
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		imds.PathPlacementAvailabilityZone:   imds.ValuePlacementAvailabilityZone,
+		imds.PathPlacementAvailabilityZoneID: imds.ValuePlacementAvailabilityZoneID,
+		imds.PathPlacementRegion:             imds.ValuePlacementRegion,
+	}, visited)
+}
+
+func TestWalk_StopsOnError(t *testing.T) {
+	container := startWithDefaults(t)
+
+	errStop := errors.New("stop")
+
+	visits := 0
+	err := container.Walk(context.Background(), imds.AllCategories, func(_, _ string) error {
+		visits++
+		return errStop
+	})
+
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, visits)
+}
+
+func TestWalk_NotFound(t *testing.T) {
+	container := startWithDefaults(t)
+
+	err := container.Walk(context.Background(), "unknown", func(_, _ string) error {
+		return nil
+	})
+
+	assert.ErrorIs(t, err, imds.ErrCategoryNotFound)
+}
+
+func TestSnapshot(t *testing.T) {
+	container := startWithDefaults(t)
+
+	snapshot, err := container.Snapshot(context.Background())
+	require.NoError(t, err)
+
+	// Just verify a subset, including nested and JSON categories
+	assert.Equal(t, imds.ValueAMIID, snapshot[imds.PathAMIID])
+	assert.Equal(t, imds.ValueBlockDeviceMappingRoot, snapshot[imds.PathBlockDeviceMappingRoot])
+	assert.Equal(t, imds.ValueIAMInfo, snapshot[imds.PathIAMInfo])
+	assert.Contains(t, snapshot[imds.PathIAMSecurityCredentials], `"AccessKeyId":"ASIA`)
+	assert.Equal(t, imds.ValueNetworkInterfaces0SubnetID, snapshot[imds.PathNetworkInterfaces0SubnetID])
+	assert.Equal(t, imds.ValuePlacementRegion, snapshot[imds.PathPlacementRegion])
+}